@@ -33,6 +33,12 @@ func WithInferenceLlm(llm model.ToolCallingChatModel) InferenceProviderOption {
 	}
 }
 
+func WithInferenceVision() InferenceProviderOption {
+	return func(i *InferenceProvider) {
+		i.Vision = true
+	}
+}
+
 func WithGetModel(getModel func() (string, error)) InferenceProviderOption {
 	return func(i *InferenceProvider) {
 		i.getModel = getModel
@@ -71,6 +77,7 @@ type InferenceProvider struct {
 	api.BasicInferenceProvider
 	Initialized bool                       `json:"-"`
 	Llm         model.ToolCallingChatModel `json:"-"`
+	Vision      bool                       `json:"-"`
 	getModel    func() (string, error)     `json:"-"`
 	installHelp func() error               `json:"-"`
 }
@@ -83,6 +90,10 @@ func (i *InferenceProvider) GetInference(_ context.Context) (model.ToolCallingCh
 	return i.Llm, nil
 }
 
+func (i *InferenceProvider) SupportsVision() bool {
+	return i.Vision
+}
+
 func (i *InferenceProvider) InstallHelp() error {
 	if i.installHelp == nil {
 		return nil
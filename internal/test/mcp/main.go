@@ -20,6 +20,13 @@ func main() {
 	server.AddTool(&mcp.Tool{Name: "test-func", Description: "A test tool", InputSchema: &jsonschema.Schema{
 		Type: "object",
 	}}, TestFunc)
+	server.AddTool(&mcp.Tool{Name: "test-read", Description: "A read-only test tool", InputSchema: &jsonschema.Schema{
+		Type: "object",
+	}, Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}, TestFunc)
+	nonDestructive := false
+	server.AddTool(&mcp.Tool{Name: "test-update", Description: "A non-destructive test tool", InputSchema: &jsonschema.Schema{
+		Type: "object",
+	}, Annotations: &mcp.ToolAnnotations{DestructiveHint: &nonDestructive}}, TestFunc)
 	if err := server.Run(context.Background(), &mcp.StdioTransport{}); err != nil {
 		panic(err)
 	}
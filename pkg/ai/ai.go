@@ -12,6 +12,8 @@ import (
 	"github.com/cloudwego/eino/schema"
 	"github.com/google/uuid"
 	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/utils"
 )
 
 type Notification struct{}
@@ -25,6 +27,22 @@ type Ai struct {
 	Output            chan Notification
 	session           *Session
 	sessionMutex      sync.RWMutex
+	// maxSteps is the step budget for each prompt
+	maxSteps int
+	// agentsParameters limits the delegation of tasks to sub-agents
+	agentsParameters api.AgentsParameters
+	// toolsParameters returns the merged parameters (read-only, disable-destructive) for a toolset
+	toolsParameters func(toolsetName string) api.ToolsParameters
+	// parent is the agent that delegated the task to this sub-agent, or nil for the main agent
+	parent *Ai
+	// depth is the nesting level of this agent (0 for the main agent)
+	depth int
+	// toolCallApprovalMutex ensures that a single tool call approval is pending at a time (tools might be called concurrently)
+	toolCallApprovalMutex sync.Mutex
+	// toolCallApprovals receives the user decisions for the pending tool call approval
+	toolCallApprovals chan api.ToolCallApprovalResponse
+	// alwaysAllowedTools are the tools the user approved for the rest of the session
+	alwaysAllowedTools map[string]bool
 
 	llm *DynamicToolCallingChatModel
 }
@@ -43,6 +61,14 @@ func New(inferenceProvider api.InferenceProvider, toolsProviders []api.ToolsProv
 		Output:            make(chan Notification),
 		session:           session,
 		sessionMutex:      sync.RWMutex{},
+		maxSteps:          DefaultMaxSteps,
+		agentsParameters: api.AgentsParameters{
+			MaxDepth:        utils.Ptr(config.DefaultAgentsMaxDepth),
+			MaxSteps:        utils.Ptr(config.DefaultAgentsMaxSteps),
+			RequireApproval: utils.Ptr(true),
+		},
+		toolCallApprovals:  make(chan api.ToolCallApprovalResponse, 1),
+		alwaysAllowedTools: make(map[string]bool),
 	}
 }
 
@@ -76,7 +102,12 @@ func (a *Ai) Session() api.Session {
 }
 
 // notify sends a notification to the Output channel to inform the UI about changes in the session state.
+// Sub-agents delegate the notification to the main agent.
 func (a *Ai) notify() {
+	if a.parent != nil {
+		a.parent.notify()
+		return
+	}
 	go func() { a.Output <- Notification{} }()
 }
 
@@ -84,6 +115,13 @@ func (a *Ai) appendMessage(message api.Message) {
 	a.sessionMutex.Lock()
 	defer a.sessionMutex.Unlock()
 	a.session.messages = append(a.session.messages, message)
+	if a.parent != nil {
+		// Sub-agent activity is mirrored (nested) in the parent's transcript
+		nested := message
+		nested.Depth++
+		a.parent.appendMessage(nested)
+		return
+	}
 	a.notify()
 }
 
@@ -116,10 +154,15 @@ func (a *Ai) Reset() {
 	a.session = &Session{
 		systemPrompt: a.session.SystemPrompt(),
 	}
+	a.alwaysAllowedTools = make(map[string]bool)
 	a.notify()
 }
 
 func (a *Ai) Run(ctx context.Context) (err error) {
+	if cfg := config.GetConfig(ctx); cfg != nil {
+		a.agentsParameters = cfg.AgentsParameters()
+		a.toolsParameters = cfg.ToolsParameters
+	}
 	// Inference Provider (LLM)
 	a.llm, err = NewDynamicToolCallingChatModel(a.inferenceProvider.GetInference(ctx))
 	if err != nil {
@@ -130,14 +173,21 @@ func (a *Ai) Run(ctx context.Context) (err error) {
 	tools = append(tools, toInvokableTools(ctx, a.toolsProviders)...)
 	a.mcpClients = StartMcpClients(ctx, a.toolsProviders)
 	tools = append(tools, ToMcpTools(ctx, a.mcpClients)...)
-	a.toolManager = NewToolManager(a.toolsProviders, tools)
+	a.toolManager = a.newToolManager(tools)
 	go func() {
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case userInput := <-a.Input():
-				a.prompt(ctx, userInput)
+				// Attachments are only resolved for the input typed by the user, the sub-agent tasks are written by
+				// the model and must not be able to read files bypassing the tools (and their sandboxes)
+				if withAttachments, err := a.withAttachments(userInput); err != nil {
+					a.appendMessage(userInput)
+					a.setError(err)
+				} else {
+					a.prompt(ctx, withAttachments)
+				}
 			}
 		}
 	}()
@@ -145,6 +195,9 @@ func (a *Ai) Run(ctx context.Context) (err error) {
 }
 
 func (a *Ai) Close() {
+	if a.toolManager != nil {
+		a.toolManager.Close()
+	}
 	StopMcpClients(a.mcpClients)
 }
 
@@ -200,9 +253,13 @@ func (a *Ai) schemaMessages() []*schema.Message {
 		schemaMessages = append(schemaMessages, schema.SystemMessage(session.SystemPrompt().Text))
 	}
 	for _, message := range session.Messages() {
+		if message.Depth > 0 {
+			// Sub-agent messages are only informative, the sub-agent result is provided as a tool message
+			continue
+		}
 		switch message.Type {
 		case api.MessageTypeUser:
-			schemaMessages = append(schemaMessages, schema.UserMessage(message.Text))
+			schemaMessages = append(schemaMessages, toSchemaUserMessage(message))
 		case api.MessageTypeAssistant:
 			schemaMessages = append(schemaMessages, schema.AssistantMessage(message.Text, nil))
 		case api.MessageTypeTool:
@@ -0,0 +1,146 @@
+package ai
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type AiApprovalSuite struct {
+	suite.Suite
+	Llm          *test.ChatModel
+	Ai           *Ai
+	deleteCalls  atomic.Int32
+	listingCalls atomic.Int32
+}
+
+func (s *AiApprovalSuite) SetupTest() {
+	s.deleteCalls.Store(0)
+	s.listingCalls.Store(0)
+	s.Llm = &test.ChatModel{}
+	s.Llm.StreamReader = func(input []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+		last := input[len(input)-1]
+		if last.Role == schema.Tool {
+			return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("Done: "+last.Content, nil)}), nil
+		}
+		return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("", []schema.ToolCall{
+			{ID: "1", Function: schema.FunctionCall{Name: "toolset_enable", Arguments: `{"toolset_names":"test-tools-provider"}`}},
+			{ID: "2", Function: schema.FunctionCall{Name: "test-tools-provider_" + last.Content, Arguments: `{"name":"pod-1"}`}},
+		})}), nil
+	}
+	toolsProvider := test.NewToolsProvider("test-tools-provider", test.WithToolsAvailable())
+	toolsProvider.Tools = []*api.Tool{{
+		Name:        "pods_delete",
+		Description: "Delete a pod",
+		Destructive: true,
+		Function: func(args map[string]interface{}) (string, error) {
+			s.deleteCalls.Add(1)
+			return "pod deleted", nil
+		},
+	}, {
+		Name:        "pods_list",
+		Description: "List pods",
+		Function: func(args map[string]interface{}) (string, error) {
+			s.listingCalls.Add(1)
+			return "pod-1", nil
+		},
+	}}
+	s.Ai = New(
+		test.NewInferenceProvider("inference-provider", test.WithInferenceAvailable(), test.WithInferenceLlm(s.Llm)),
+		[]api.ToolsProvider{toolsProvider},
+	)
+	if err := s.Ai.Run(config.WithConfig(s.T().Context(), config.New())); err != nil {
+		s.T().Fatalf("failed to run AI: %v", err)
+	}
+}
+
+func (s *AiApprovalSuite) TearDownTest() {
+	s.Ai.Close()
+}
+
+func (s *AiApprovalSuite) WaitForApproval() *api.ToolCallApproval {
+	s.Require().Eventually(func() bool { return s.Ai.Session().PendingToolCallApproval() != nil }, 10*time.Second, 10*time.Millisecond, "Expected tool call to require approval")
+	return s.Ai.Session().PendingToolCallApproval()
+}
+
+func (s *AiApprovalSuite) WaitForRunToComplete() {
+	s.Eventually(func() bool { return !s.Ai.Session().IsRunning() }, 10*time.Second, 10*time.Millisecond, "Expected AI session to finish")
+}
+
+func (s *AiApprovalSuite) TestApprove() {
+	s.Ai.Input() <- api.NewUserMessage("pods_delete")
+	approval := s.WaitForApproval()
+	s.Run("Pauses the turn with the tool call details", func() {
+		s.Equal("test-tools-provider_pods_delete", approval.ToolName)
+		s.Equal("{\n  \"name\": \"pod-1\"\n}", approval.Arguments)
+		s.True(s.Ai.Session().IsRunning())
+		s.Equal(int32(0), s.deleteCalls.Load())
+	})
+	s.Ai.RespondToolCallApproval(api.ToolCallApprovalResponse{Approved: true})
+	s.WaitForRunToComplete()
+	s.Run("Calls the tool", func() {
+		s.Equal(int32(1), s.deleteCalls.Load())
+		s.Contains(s.Ai.Session().Messages(), api.NewToolMessage("pod deleted", "test-tools-provider_pods_delete"))
+	})
+	s.Run("Clears the pending approval", func() {
+		s.Nil(s.Ai.Session().PendingToolCallApproval())
+	})
+}
+
+func (s *AiApprovalSuite) TestDeny() {
+	s.Ai.Input() <- api.NewUserMessage("pods_delete")
+	s.WaitForApproval()
+	s.Ai.RespondToolCallApproval(api.ToolCallApprovalResponse{Reason: "production cluster"})
+	s.WaitForRunToComplete()
+	s.Run("Does not call the tool", func() {
+		s.Equal(int32(0), s.deleteCalls.Load())
+	})
+	s.Run("Denial is provided to the model as the tool result", func() {
+		s.Contains(s.Ai.Session().Messages(), api.NewAssistantMessage("Done: The user denied the call to the 'test-tools-provider_pods_delete' tool. Reason: production cluster"))
+	})
+}
+
+func (s *AiApprovalSuite) TestAlwaysAllow() {
+	s.Ai.Input() <- api.NewUserMessage("pods_delete")
+	s.WaitForApproval()
+	s.Ai.RespondToolCallApproval(api.ToolCallApprovalResponse{Approved: true, AlwaysAllow: true})
+	s.WaitForRunToComplete()
+	s.Ai.Input() <- api.NewUserMessage("pods_delete")
+	s.Require().Eventually(func() bool { return s.deleteCalls.Load() == 2 }, 10*time.Second, 10*time.Millisecond, "Expected tool to be called without approval")
+	s.WaitForRunToComplete()
+	s.Run("Reset requires approval again", func() {
+		s.Ai.Reset()
+		s.Ai.Input() <- api.NewUserMessage("pods_delete")
+		s.WaitForApproval()
+		s.Ai.RespondToolCallApproval(api.ToolCallApprovalResponse{Approved: true})
+		s.WaitForRunToComplete()
+		s.Equal(int32(3), s.deleteCalls.Load())
+	})
+}
+
+func (s *AiApprovalSuite) TestNonDestructiveToolsDoNotRequireApproval() {
+	s.Ai.Input() <- api.NewUserMessage("pods_list")
+	s.WaitForRunToComplete()
+	s.Equal(int32(1), s.listingCalls.Load())
+	s.Contains(s.Ai.Session().Messages(), api.NewAssistantMessage("Done: pod-1"))
+}
+
+func (s *AiApprovalSuite) TestResponseWithoutPendingApprovalIsIgnored() {
+	s.Ai.RespondToolCallApproval(api.ToolCallApprovalResponse{Approved: true})
+	s.Ai.Input() <- api.NewUserMessage("pods_delete")
+	s.WaitForApproval()
+	s.Equal(int32(0), s.deleteCalls.Load())
+	s.Ai.RespondToolCallApproval(api.ToolCallApprovalResponse{})
+	s.WaitForRunToComplete()
+}
+
+func TestAiApproval(t *testing.T) {
+	suite.Run(t, new(AiApprovalSuite))
+}
@@ -0,0 +1,141 @@
+package ai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/suite"
+)
+
+var pngData = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type AiAttachmentsSuite struct {
+	suite.Suite
+	Llm                *test.ChatModel
+	InferenceProvider  *test.InferenceProvider
+	Ai                 *Ai
+	receivedMessages   []*schema.Message
+	originalFileSystem afero.Fs
+}
+
+func (s *AiAttachmentsSuite) SetupTest() {
+	s.originalFileSystem = config.FileSystem
+	config.FileSystem = afero.NewMemMapFs()
+	_ = afero.WriteFile(config.FileSystem, "notes.txt", []byte("Some notes"), 0644)
+	_ = afero.WriteFile(config.FileSystem, "panel.png", pngData, 0644)
+	s.Llm = &test.ChatModel{}
+	s.receivedMessages = nil
+	s.Llm.StreamReader = func(input []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+		s.receivedMessages = input
+		return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("Done", nil)}), nil
+	}
+	s.InferenceProvider = test.NewInferenceProvider("inference-provider", test.WithInferenceAvailable(), test.WithInferenceLlm(s.Llm))
+	s.Ai = New(s.InferenceProvider, []api.ToolsProvider{})
+	if err := s.Ai.Run(config.WithConfig(s.T().Context(), config.New())); err != nil {
+		s.T().Fatalf("failed to run AI: %v", err)
+	}
+}
+
+func (s *AiAttachmentsSuite) TearDownTest() {
+	config.FileSystem = s.originalFileSystem
+	s.Ai.Close()
+}
+
+func (s *AiAttachmentsSuite) WaitForRunToComplete() {
+	s.Eventually(func() bool { return !s.Ai.Session().IsRunning() }, 10*time.Second, 100*time.Millisecond, "Expected AI session to finish")
+}
+
+func (s *AiAttachmentsSuite) TestTextAttachment() {
+	s.Ai.Input() <- api.NewUserMessage("Summarize @notes.txt please")
+	s.WaitForRunToComplete()
+	s.Require().NotEmpty(s.receivedMessages)
+	s.Run("Stores attachment in user message", func() {
+		s.Require().Len(s.Ai.Session().Messages()[0].Attachments, 1)
+		s.Equal("notes.txt", s.Ai.Session().Messages()[0].Attachments[0].Name)
+		s.Equal("text/plain", s.Ai.Session().Messages()[0].Attachments[0].MimeType)
+	})
+	s.Run("Text attachment is provided inline", func() {
+		s.Empty(s.receivedMessages[0].MultiContent)
+		s.Equal("Summarize @notes.txt please\n<attachment name=\"notes.txt\">\nSome notes\n</attachment>", s.receivedMessages[0].Content)
+	})
+}
+
+func (s *AiAttachmentsSuite) TestImageAttachmentWithVision() {
+	s.InferenceProvider.Vision = true
+	s.Ai.Input() <- api.NewUserMessage("What's in @panel.png")
+	s.WaitForRunToComplete()
+	s.Require().NotEmpty(s.receivedMessages)
+	s.Require().Len(s.receivedMessages[0].MultiContent, 2)
+	s.Run("First part is the text", func() {
+		s.Equal(schema.ChatMessagePartTypeText, s.receivedMessages[0].MultiContent[0].Type)
+		s.Equal("What's in @panel.png", s.receivedMessages[0].MultiContent[0].Text)
+	})
+	s.Run("Second part is the image as a data URL", func() {
+		s.Equal(schema.ChatMessagePartTypeImageURL, s.receivedMessages[0].MultiContent[1].Type)
+		s.Equal("image/png", s.receivedMessages[0].MultiContent[1].ImageURL.MIMEType)
+		s.Equal("data:image/png;base64,iVBORw0KGgoAAAANSUhEUg==", s.receivedMessages[0].MultiContent[1].ImageURL.URL)
+	})
+}
+
+func (s *AiAttachmentsSuite) TestImageAttachmentWithoutVision() {
+	s.Ai.Input() <- api.NewUserMessage("What's in @panel.png")
+	s.WaitForRunToComplete()
+	s.Run("Model is not called", func() {
+		s.Nil(s.receivedMessages)
+	})
+	s.Run("Sets clear error", func() {
+		s.Contains(s.Ai.Session().Messages(), api.NewErrorMessage("the selected model (inference-provider) does not support image/png attachments (panel.png), only text files can be attached"))
+	})
+}
+
+func (s *AiAttachmentsSuite) TestAttachmentWithTrailingPunctuation() {
+	s.Ai.Input() <- api.NewUserMessage("Summarize @notes.txt.")
+	s.WaitForRunToComplete()
+	s.Require().NotEmpty(s.receivedMessages)
+	s.Run("Trailing punctuation is not part of the path", func() {
+		s.Require().Len(s.Ai.Session().Messages()[0].Attachments, 1)
+		s.Equal("notes.txt", s.Ai.Session().Messages()[0].Attachments[0].Name)
+	})
+}
+
+func (s *AiAttachmentsSuite) TestNonFileMentions() {
+	s.Ai.Input() <- api.NewUserMessage("Ask @alice about the @Override annotation in @missing.txt and @.")
+	s.WaitForRunToComplete()
+	s.Require().NotEmpty(s.receivedMessages)
+	s.Run("Model is called with the plain text", func() {
+		s.Equal("Ask @alice about the @Override annotation in @missing.txt and @.", s.receivedMessages[0].Content)
+	})
+	s.Run("No attachments are stored", func() {
+		s.Empty(s.Ai.Session().Messages()[0].Attachments)
+	})
+}
+
+func (s *AiAttachmentsSuite) TestDirectoryMention() {
+	_ = config.FileSystem.MkdirAll("src", 0755)
+	s.Ai.Input() <- api.NewUserMessage("Look at @src")
+	s.WaitForRunToComplete()
+	s.Require().NotEmpty(s.receivedMessages)
+	s.Empty(s.Ai.Session().Messages()[0].Attachments)
+}
+
+func (s *AiAttachmentsSuite) TestUnsupportedAttachment() {
+	_ = afero.WriteFile(config.FileSystem, "archive.zip", []byte("PK\x03\x04"), 0644)
+	s.Ai.Input() <- api.NewUserMessage("Extract @archive.zip")
+	s.WaitForRunToComplete()
+	s.Run("Model is not called", func() {
+		s.Nil(s.receivedMessages)
+	})
+	s.Run("Sets error", func() {
+		s.Contains(s.Ai.Session().Messages(), api.NewErrorMessage("failed to attach archive.zip: unsupported file type application/zip"))
+	})
+}
+
+func TestAiAttachments(t *testing.T) {
+	suite.Run(t, new(AiAttachmentsSuite))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/manusa/ai-cli/internal/test"
 	"github.com/manusa/ai-cli/pkg/api"
 	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/policies"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -54,7 +55,7 @@ func (s *AiMcpSuite) TestCloseShutsDownMcpServers() {
 	})
 }
 
-func (s *AiMcpSuite) TestNewReActAgentAddsOnlyEnableTool() {
+func (s *AiMcpSuite) TestNewReActAgentAddsOnlyBuiltInTools() {
 	var receivedTools []*schema.ToolInfo
 	s.Llm.WithToolsFunc = func(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
 		receivedTools = tools
@@ -62,12 +63,98 @@ func (s *AiMcpSuite) TestNewReActAgentAddsOnlyEnableTool() {
 	}
 	s.Ai.Input() <- api.NewUserMessage("Hello AItana! I'm sending some MCP toolManager.")
 	s.Require().Eventually(func() bool { return len(receivedTools) > 0 }, 10*time.Second, 100, "Expected LLM to be called with toolManager")
-	s.Run("Tools includes a single tool", func() {
-		s.Len(receivedTools, 1, "Expected only one tool to be passed to LLM")
+	s.Run("Tools includes only built-in tools", func() {
+		s.Len(receivedTools, 5, "Expected only built-in tools to be passed to LLM")
 	})
 	s.Run("Tools includes toolset_enable", func() {
 		s.True(slices.ContainsFunc(receivedTools, func(t *schema.ToolInfo) bool { return t.Name == "toolset_enable" }), "Expected to find MCP 'toolset_enable' tool in received toolManager")
 	})
+	s.Run("Tools includes toolset_disable", func() {
+		s.True(slices.ContainsFunc(receivedTools, func(t *schema.ToolInfo) bool { return t.Name == "toolset_disable" }), "Expected to find 'toolset_disable' tool in received toolManager")
+	})
+	s.Run("Tools includes toolset_list", func() {
+		s.True(slices.ContainsFunc(receivedTools, func(t *schema.ToolInfo) bool { return t.Name == "toolset_list" }), "Expected to find 'toolset_list' tool in received toolManager")
+	})
+	s.Run("Tools includes tool_output_read", func() {
+		s.True(slices.ContainsFunc(receivedTools, func(t *schema.ToolInfo) bool { return t.Name == "tool_output_read" }), "Expected to find 'tool_output_read' tool in received toolManager")
+	})
+	s.Run("Tools includes subagent_run", func() {
+		s.True(slices.ContainsFunc(receivedTools, func(t *schema.ToolInfo) bool { return t.Name == "subagent_run" }), "Expected to find 'subagent_run' tool in received toolManager")
+	})
+}
+
+func (s *AiMcpSuite) TestToolAnnotations() {
+	toolNames := func(ai *Ai) []string {
+		var names []string
+		for _, t := range ai.toolManager.availableTools {
+			names = append(names, t.ToolInfo().Name)
+		}
+		return names
+	}
+	runWithPolicies := func(policiesToml string) *Ai {
+		cfg := config.New()
+		cfg.Enforce(test.Must(policies.ReadToml(policiesToml)))
+		ai := New(
+			test.NewInferenceProvider("inference-provider", test.WithInferenceAvailable(), test.WithInferenceLlm(s.Llm)),
+			[]api.ToolsProvider{test.NewToolsProvider("test-toolManager-provider", test.WithToolsAvailable(), test.WithToolsMcpSettings(test.McpServer()))},
+		)
+		s.Require().NoError(ai.Run(config.WithConfig(s.T().Context(), cfg)))
+		s.T().Cleanup(ai.Close)
+		return ai
+	}
+	s.Run("All tools are available by default", func() {
+		s.ElementsMatch([]string{"test-toolManager-provider_test-func", "test-toolManager-provider_test-read", "test-toolManager-provider_test-update"}, toolNames(s.Ai))
+	})
+	s.Run("Read-only policy keeps only read-only annotated tools", func() {
+		ai := runWithPolicies("[tools]\nread-only = true")
+		s.Equal([]string{"test-toolManager-provider_test-read"}, toolNames(ai))
+		s.Run("Restricted tool calls are blocked", func() {
+			result, err := ai.toolManager.InvokeTool(s.T().Context(), "test-toolManager-provider_test-update", "{}")
+			s.NoError(err)
+			s.Equal("Tool 'test-toolManager-provider_test-update' is not available because the 'test-toolManager-provider' toolset is read-only.", result)
+		})
+	})
+	s.Run("Non-destructive policy removes destructive and non-annotated tools", func() {
+		ai := runWithPolicies("[tools]\nnon-destructive = true")
+		s.ElementsMatch([]string{"test-toolManager-provider_test-read", "test-toolManager-provider_test-update"}, toolNames(ai))
+		s.Run("Restricted tool calls are blocked", func() {
+			result, err := ai.toolManager.InvokeTool(s.T().Context(), "test-toolManager-provider_test-func", "{}")
+			s.NoError(err)
+			s.Equal("Tool 'test-toolManager-provider_test-func' is not available because the 'test-toolManager-provider' toolset does not allow destructive tools.", result)
+		})
+	})
+	s.Run("Provider policies only affect the provider tools", func() {
+		ai := runWithPolicies("[tools.provider.other-provider]\nread-only = true")
+		s.ElementsMatch([]string{"test-toolManager-provider_test-func", "test-toolManager-provider_test-read", "test-toolManager-provider_test-update"}, toolNames(ai))
+	})
+}
+
+func (s *AiMcpSuite) TestToolNamesAreNamespacedByToolset() {
+	ai := New(
+		test.NewInferenceProvider("inference-provider", test.WithInferenceAvailable(), test.WithInferenceLlm(s.Llm)),
+		[]api.ToolsProvider{
+			test.NewToolsProvider("server-a", test.WithToolsAvailable(), test.WithToolsMcpSettings(test.McpServer())),
+			test.NewToolsProvider("server-b", test.WithToolsAvailable(), test.WithToolsMcpSettings(test.McpServer())),
+		},
+	)
+	s.Require().NoError(ai.Run(config.WithConfig(s.T().Context(), config.New())))
+	s.T().Cleanup(ai.Close)
+	s.Run("Tools with the same name in different servers don't collide", func() {
+		_, _ = ai.toolManager.toolsetEnable(map[string]interface{}{"toolset_names": "server-a,server-b"})
+		s.Equal(6, ai.ToolEnabledCount())
+	})
+	s.Run("MCP server is called with the original tool name", func() {
+		result, err := ai.toolManager.InvokeTool(s.T().Context(), "server-b_test-read", "{}")
+		s.NoError(err)
+		s.Contains(result, "test-works")
+	})
+	s.Run("InspectTools reports duplicate tool names", func() {
+		s.Equal([]api.DuplicateTool{
+			{Name: "test-func", Toolsets: []string{"server-a", "server-b"}},
+			{Name: "test-read", Toolsets: []string{"server-a", "server-b"}},
+			{Name: "test-update", Toolsets: []string{"server-a", "server-b"}},
+		}, InspectTools(s.T().Context(), ai.toolsProviders).DuplicateTools)
+	})
 }
 
 func TestAiMcp(t *testing.T) {
@@ -0,0 +1,123 @@
+package ai
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type AiParallelSuite struct {
+	suite.Suite
+	Ai                 *Ai
+	concurrentCalls    atomic.Int32
+	maxConcurrentCalls atomic.Int32
+}
+
+func (s *AiParallelSuite) SetupTest() {
+	s.concurrentCalls.Store(0)
+	s.maxConcurrentCalls.Store(0)
+	llm := &test.ChatModel{}
+	llm.StreamReader = func(input []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+		if input[len(input)-1].Role == schema.Tool {
+			return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("Done", nil)}), nil
+		}
+		var toolCalls []schema.ToolCall
+		for i, delay := range []int{60, 30, 0} {
+			toolCalls = append(toolCalls, schema.ToolCall{ID: fmt.Sprintf("%d", i), Function: schema.FunctionCall{
+				Name: "test-tools-provider_wait", Arguments: fmt.Sprintf(`{"delay":%d}`, delay),
+			}})
+		}
+		return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("", toolCalls)}), nil
+	}
+	toolsProvider := test.NewToolsProvider("test-tools-provider", test.WithToolsAvailable())
+	toolsProvider.Tools = []*api.Tool{{
+		Name:        "wait",
+		Description: "Wait for the provided delay in milliseconds",
+		Parameters:  map[string]api.ToolParameter{"delay": {Type: api.Integer, Required: true}},
+		Function: func(args map[string]interface{}) (string, error) {
+			concurrentCalls := s.concurrentCalls.Add(1)
+			defer s.concurrentCalls.Add(-1)
+			for maxConcurrentCalls := s.maxConcurrentCalls.Load(); concurrentCalls > maxConcurrentCalls; maxConcurrentCalls = s.maxConcurrentCalls.Load() {
+				if s.maxConcurrentCalls.CompareAndSwap(maxConcurrentCalls, concurrentCalls) {
+					break
+				}
+			}
+			time.Sleep(time.Duration(args["delay"].(float64)) * time.Millisecond)
+			return fmt.Sprintf("waited %vms", args["delay"]), nil
+		},
+	}}
+	s.Ai = New(
+		test.NewInferenceProvider("inference-provider", test.WithInferenceAvailable(), test.WithInferenceLlm(llm)),
+		[]api.ToolsProvider{toolsProvider},
+	)
+	if err := s.Ai.Run(config.WithConfig(s.T().Context(), config.New())); err != nil {
+		s.T().Fatalf("failed to run AI: %v", err)
+	}
+	s.Ai.agentsParameters.ParallelToolCalls = utils.Ptr(true)
+	_, _ = s.Ai.toolManager.toolsetEnable(map[string]interface{}{"toolset_names": "test-tools-provider"})
+}
+
+func (s *AiParallelSuite) TearDownTest() {
+	s.Ai.Close()
+}
+
+func (s *AiParallelSuite) prompt() []api.Message {
+	s.Ai.Input() <- api.NewUserMessage("wait")
+	s.Require().Eventually(func() bool {
+		messages := s.Ai.Session().Messages()
+		return len(messages) > 0 && messages[len(messages)-1].Text == "Done"
+	}, 10*time.Second, 10*time.Millisecond, "Expected AI session to finish")
+	return s.Ai.Session().Messages()
+}
+
+func (s *AiParallelSuite) TestParallelToolCalls() {
+	messages := s.prompt()
+	s.Run("Tools are called concurrently", func() {
+		s.Greater(s.maxConcurrentCalls.Load(), int32(1))
+	})
+	s.Run("Tool messages are appended in the order requested by the model", func() {
+		s.Equal([]api.Message{
+			api.NewToolMessage("waited 60ms", "test-tools-provider_wait"),
+			api.NewToolMessage("waited 30ms", "test-tools-provider_wait"),
+			api.NewToolMessage("waited 0ms", "test-tools-provider_wait"),
+		}, messages[1:4])
+	})
+}
+
+func (s *AiParallelSuite) TestToolsetMaxConcurrency() {
+	s.Ai.toolManager.limitConcurrency(func(string) api.ToolsParameters {
+		return api.ToolsParameters{MaxConcurrency: utils.Ptr(1)}
+	})
+	messages := s.prompt()
+	s.Run("Tools are called one at a time", func() {
+		s.Equal(int32(1), s.maxConcurrentCalls.Load())
+	})
+	s.Run("Tool messages are appended in the order requested by the model", func() {
+		s.Equal(api.NewToolMessage("waited 60ms", "test-tools-provider_wait"), messages[1])
+		s.Equal(api.NewToolMessage("waited 0ms", "test-tools-provider_wait"), messages[3])
+	})
+}
+
+func (s *AiParallelSuite) TestSequentialToolCalls() {
+	s.Ai.agentsParameters.ParallelToolCalls = utils.Ptr(false)
+	messages := s.prompt()
+	s.Run("Tools are called one at a time", func() {
+		s.Equal(int32(1), s.maxConcurrentCalls.Load())
+	})
+	s.Run("Tool messages are appended in the order requested by the model", func() {
+		s.Equal(api.NewToolMessage("waited 30ms", "test-tools-provider_wait"), messages[2])
+	})
+}
+
+func TestAiParallel(t *testing.T) {
+	suite.Run(t, new(AiParallelSuite))
+}
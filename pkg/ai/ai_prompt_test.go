@@ -2,6 +2,7 @@ package ai
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/manusa/ai-cli/internal/test"
 	"github.com/manusa/ai-cli/pkg/api"
 	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/tools/fs"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -123,6 +125,43 @@ func (s *AiPromptSuite) TestInput_SendsPrompt_WithSessionMessages() {
 	})
 }
 
+func (s *AiPromptSuite) TestInput_ToolFailureIsProvidedToTheModel() {
+	toolsProvider := test.NewToolsProvider("fs", test.WithToolsAvailable())
+	toolsProvider.Tools = []*api.Tool{fs.FileRead}
+	s.Ai.Close()
+	s.Ai = New(
+		test.NewInferenceProvider("inference-provider", test.WithInferenceAvailable(), test.WithInferenceLlm(s.Llm)),
+		[]api.ToolsProvider{toolsProvider},
+	)
+	s.Require().NoError(s.Ai.Run(config.WithConfig(s.T().Context(), config.New())))
+	s.T().Cleanup(s.Ai.Close)
+	_, _ = s.Ai.toolManager.toolsetEnable(map[string]interface{}{"toolset_names": "fs"})
+	s.Llm.StreamReader = func(input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+		if input[len(input)-1].Role == schema.Tool {
+			return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("The file doesn't exist", nil)}), nil
+		}
+		return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("", []schema.ToolCall{
+			{ID: "1", Function: schema.FunctionCall{Name: "fs_file_read", Arguments: `{"path":"missing-file.txt"}`}},
+		})}), nil
+	}
+	s.Ai.Input() <- api.NewUserMessage("Read missing-file.txt")
+	s.Require().Eventually(func() bool {
+		messages := s.Ai.Session().Messages()
+		return len(messages) > 0 && messages[len(messages)-1].Type == api.MessageTypeAssistant
+	}, 10*time.Second, 100*time.Millisecond, "Expected the agent turn to continue")
+	s.WaitForRunToComplete()
+	messages := s.Ai.Session().Messages()
+	s.Run("Tool failure is provided as the tool result", func() {
+		s.Require().Len(messages, 3)
+		s.Equal(api.MessageTypeTool, messages[1].Type)
+		s.True(strings.HasSuffix(messages[1].Text, "missing-file.txt does not exist."), messages[1].Text)
+	})
+	s.Run("Agent turn continues with the model answer", func() {
+		s.Equal(api.NewAssistantMessage("The file doesn't exist"), messages[2])
+		s.NoError(s.Ai.session.error)
+	})
+}
+
 func TestAiPrompt(t *testing.T) {
 	suite.Run(t, new(AiPromptSuite))
 }
@@ -0,0 +1,228 @@
+package ai
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/policies"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/suite"
+)
+
+type AiSubAgentSuite struct {
+	suite.Suite
+	Llm           *test.ChatModel
+	Ai            *Ai
+	receivedTools [][]*schema.ToolInfo
+	mu            sync.Mutex
+}
+
+func (s *AiSubAgentSuite) SetupTest() {
+	s.receivedTools = nil
+	s.Llm = &test.ChatModel{}
+	s.Llm.WithToolsFunc = func(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.receivedTools = append(s.receivedTools, tools)
+		return s.Llm, nil
+	}
+	s.Llm.StreamReader = func(input []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+		firstUserMessage := input[slices.IndexFunc(input, func(m *schema.Message) bool { return m.Role == schema.User })]
+		toolMessage := slices.IndexFunc(input, func(m *schema.Message) bool { return m.Role == schema.Tool })
+		switch {
+		case firstUserMessage.Content == "Count the files":
+			return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("There are 3 files", nil)}), nil
+		case toolMessage >= 0:
+			return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("Parent answer: "+input[toolMessage].Content, nil)}), nil
+		}
+		return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("", []schema.ToolCall{
+			{ID: "1337", Function: schema.FunctionCall{
+				Name:      "subagent_run",
+				Arguments: `{"task":"Count the files","toolset_names":"test-tools-provider"}`,
+			}},
+		})}), nil
+	}
+}
+
+func (s *AiSubAgentSuite) TearDownTest() {
+	if s.Ai != nil {
+		s.Ai.Close()
+	}
+}
+
+func (s *AiSubAgentSuite) startAi(cfg *config.Config) {
+	toolsProvider := test.NewToolsProvider("test-tools-provider", test.WithToolsAvailable())
+	toolsProvider.Tools = []*api.Tool{{
+		Name:        "file_list",
+		Description: "A test tool",
+		Function: func(args map[string]interface{}) (string, error) {
+			return "file1.txt, file2.txt, file3.txt", nil
+		},
+	}}
+	s.Ai = New(
+		test.NewInferenceProvider("inference-provider", test.WithInferenceAvailable(), test.WithInferenceLlm(s.Llm)),
+		[]api.ToolsProvider{toolsProvider, test.NewToolsProvider("other-tools-provider", test.WithToolsAvailable())},
+	)
+	if err := s.Ai.Run(config.WithConfig(s.T().Context(), cfg)); err != nil {
+		s.T().Fatalf("failed to run AI: %v", err)
+	}
+}
+
+func (s *AiSubAgentSuite) WaitForRunToComplete() {
+	s.Eventually(func() bool { return !s.Ai.Session().IsRunning() }, 10*time.Second, 100*time.Millisecond, "Expected AI session to finish")
+}
+
+func (s *AiSubAgentSuite) TestSubAgentRun() {
+	s.startAi(config.New())
+	s.Ai.Input() <- api.NewUserMessage("Compare things")
+	s.Require().Eventually(func() bool {
+		return slices.ContainsFunc(s.Ai.Session().Messages(), func(m api.Message) bool {
+			return m.Type == api.MessageTypeAssistant && m.Text == "Parent answer: There are 3 files"
+		})
+	}, 10*time.Second, 100*time.Millisecond, "Expected parent to receive the sub-agent answer")
+	s.WaitForRunToComplete()
+	messages := s.Ai.Session().Messages()
+	s.Run("Sub-agent final answer is returned as tool result", func() {
+		s.Contains(messages, api.NewToolMessage("There are 3 files", "subagent_run"))
+	})
+	s.Run("Sub-agent task is nested in the transcript", func() {
+		s.Contains(messages, api.Message{Type: api.MessageTypeUser, Text: "Count the files", Depth: 1})
+	})
+	s.Run("Sub-agent answer is nested in the transcript", func() {
+		s.Contains(messages, api.Message{Type: api.MessageTypeAssistant, Text: "There are 3 files", Depth: 1})
+	})
+	s.Run("Sub-agent messages are not sent to the parent LLM", func() {
+		schemaMessages := s.Ai.schemaMessages()
+		s.False(slices.ContainsFunc(schemaMessages, func(m *schema.Message) bool { return m.Content == "Count the files" }))
+	})
+	s.Run("Sub-agent has the restricted toolsets enabled", func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.True(slices.ContainsFunc(s.receivedTools, func(tools []*schema.ToolInfo) bool {
+			return slices.ContainsFunc(tools, func(t *schema.ToolInfo) bool { return t.Name == "test-tools-provider_file_list" })
+		}), "Expected sub-agent to receive the tools of the restricted toolset")
+	})
+	s.Run("Sub-agent can't nest further with default max depth", func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.True(slices.ContainsFunc(s.receivedTools, func(tools []*schema.ToolInfo) bool {
+			return !slices.ContainsFunc(tools, func(t *schema.ToolInfo) bool { return t.Name == "subagent_run" })
+		}), "Expected sub-agent not to receive the subagent_run tool")
+	})
+}
+
+func (s *AiSubAgentSuite) TestSubAgentInvalidToolsetNames() {
+	for _, tc := range []struct {
+		name         string
+		toolsetNames string
+		expected     string
+	}{
+		{"unknown toolset names are rejected", "test-tools-provider, unknown, other",
+			"Invalid toolset names: unknown, other. Valid toolset names: test-tools-provider, other-tools-provider"},
+		{"empty toolset names are rejected", " , ",
+			"At least one toolset name is required. Valid toolset names: test-tools-provider, other-tools-provider"},
+	} {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			defer s.TearDownTest()
+			s.Llm.StreamReader = func(input []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+				if toolMessage := slices.IndexFunc(input, func(m *schema.Message) bool { return m.Role == schema.Tool }); toolMessage >= 0 {
+					return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("Done", nil)}), nil
+				}
+				return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("", []schema.ToolCall{
+					{ID: "1337", Function: schema.FunctionCall{
+						Name:      "subagent_run",
+						Arguments: `{"task":"Count the files","toolset_names":"` + tc.toolsetNames + `"}`,
+					}},
+				})}), nil
+			}
+			s.startAi(config.New())
+			s.Ai.Input() <- api.NewUserMessage("Compare things")
+			s.Require().Eventually(func() bool {
+				return slices.ContainsFunc(s.Ai.Session().Messages(), func(m api.Message) bool {
+					return m.Type == api.MessageTypeTool && m.Text == tc.expected
+				})
+			}, 10*time.Second, 100*time.Millisecond, "Expected invalid toolset names to be reported to the model")
+			s.WaitForRunToComplete()
+			s.False(slices.ContainsFunc(s.Ai.Session().Messages(), func(m api.Message) bool { return m.Depth > 0 }), "Expected no sub-agent to run")
+		})
+	}
+}
+
+func (s *AiSubAgentSuite) TestSubAgentTaskAttachmentsAreNotResolved() {
+	originalFileSystem := config.FileSystem
+	defer func() { config.FileSystem = originalFileSystem }()
+	config.FileSystem = afero.NewMemMapFs()
+	_ = afero.WriteFile(config.FileSystem, "id_rsa", []byte("PRIVATE KEY"), 0600)
+	var subAgentInput []*schema.Message
+	s.Llm.StreamReader = func(input []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+		firstUserMessage := input[slices.IndexFunc(input, func(m *schema.Message) bool { return m.Role == schema.User })]
+		toolMessage := slices.IndexFunc(input, func(m *schema.Message) bool { return m.Role == schema.Tool })
+		switch {
+		case firstUserMessage.Content == "Summarize @id_rsa":
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			subAgentInput = input
+			return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("Nothing to summarize", nil)}), nil
+		case toolMessage >= 0:
+			return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("Done", nil)}), nil
+		}
+		return schema.StreamReaderFromArray([]*schema.Message{schema.AssistantMessage("", []schema.ToolCall{
+			{ID: "1337", Function: schema.FunctionCall{
+				Name:      "subagent_run",
+				Arguments: `{"task":"Summarize @id_rsa","toolset_names":"test-tools-provider"}`,
+			}},
+		})}), nil
+	}
+	s.startAi(config.New())
+	s.Ai.Input() <- api.NewUserMessage("Check the keys")
+	s.Require().Eventually(func() bool {
+		return slices.ContainsFunc(s.Ai.Session().Messages(), func(m api.Message) bool {
+			return m.Type == api.MessageTypeTool && m.Text == "Nothing to summarize"
+		})
+	}, 10*time.Second, 100*time.Millisecond, "Expected parent to receive the sub-agent answer")
+	s.WaitForRunToComplete()
+	s.Run("Sub-agent task is stored without attachments", func() {
+		s.Contains(s.Ai.Session().Messages(), api.Message{Type: api.MessageTypeUser, Text: "Summarize @id_rsa", Depth: 1})
+	})
+	s.Run("Sub-agent model doesn't receive the file content", func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.Require().NotNil(subAgentInput)
+		s.False(slices.ContainsFunc(subAgentInput, func(m *schema.Message) bool {
+			return strings.Contains(m.Content, "PRIVATE KEY") || len(m.MultiContent) > 0
+		}))
+	})
+}
+
+func (s *AiSubAgentSuite) TestSubAgentDisabledByPolicy() {
+	cfg := config.New()
+	cfg.Enforce(test.Must(policies.ReadToml(`
+[agents]
+max-depth = 0
+`)))
+	s.startAi(cfg)
+	s.Llm.StreamReader = nil
+	s.Ai.Input() <- api.NewUserMessage("Hello AItana!")
+	s.WaitForRunToComplete()
+	s.Run("subagent_run tool is not provided", func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.Require().NotEmpty(s.receivedTools)
+		for _, tools := range s.receivedTools {
+			s.False(slices.ContainsFunc(tools, func(t *schema.ToolInfo) bool { return t.Name == "subagent_run" }))
+		}
+	})
+}
+
+func TestAiSubAgent(t *testing.T) {
+	suite.Run(t, new(AiSubAgentSuite))
+}
@@ -0,0 +1,73 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/manusa/ai-cli/pkg/api"
+)
+
+// approveToolCall asks the user for approval before calling a destructive tool and waits (pausing the turn) for the decision.
+// Sub-agents delegate the approval to the main agent, which owns the session displayed to the user.
+func (a *Ai) approveToolCall(ctx context.Context, toolName, arguments string) (approved bool, reason string) {
+	if a.parent != nil {
+		return a.parent.approveToolCall(ctx, toolName, arguments)
+	}
+	a.toolCallApprovalMutex.Lock()
+	defer a.toolCallApprovalMutex.Unlock()
+	a.sessionMutex.Lock()
+	if a.alwaysAllowedTools[toolName] {
+		a.sessionMutex.Unlock()
+		return true, ""
+	}
+	// Discard any stale decision provided when no approval was pending
+	select {
+	case <-a.toolCallApprovals:
+	default:
+	}
+	a.session.pendingToolCallApproval = &api.ToolCallApproval{ToolName: toolName, Arguments: prettyPrint(arguments)}
+	a.notify()
+	a.sessionMutex.Unlock()
+	defer func() {
+		a.sessionMutex.Lock()
+		defer a.sessionMutex.Unlock()
+		a.session.pendingToolCallApproval = nil
+		a.notify()
+	}()
+	select {
+	case <-ctx.Done():
+		return false, "the tool call was cancelled"
+	case response := <-a.toolCallApprovals:
+		if response.AlwaysAllow {
+			a.sessionMutex.Lock()
+			a.alwaysAllowedTools[toolName] = true
+			a.sessionMutex.Unlock()
+			return true, ""
+		}
+		return response.Approved, response.Reason
+	}
+}
+
+// RespondToolCallApproval provides the user decision for the tool call pending approval.
+// The response is ignored if there is no tool call pending approval.
+func (a *Ai) RespondToolCallApproval(response api.ToolCallApprovalResponse) {
+	a.sessionMutex.RLock()
+	pending := a.session.pendingToolCallApproval != nil
+	a.sessionMutex.RUnlock()
+	if !pending {
+		return
+	}
+	select {
+	case a.toolCallApprovals <- response:
+	default: // A decision for the pending tool call was already provided
+	}
+}
+
+func prettyPrint(argumentsInJSON string) string {
+	pretty := bytes.Buffer{}
+	if err := json.Indent(&pretty, []byte(argumentsInJSON), "", "  "); err != nil {
+		return argumentsInJSON
+	}
+	return pretty.String()
+}
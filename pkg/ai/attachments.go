@@ -0,0 +1,113 @@
+package ai
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/spf13/afero"
+)
+
+const maxAttachmentSize = 10 * 1024 * 1024
+
+// attachmentReference matches the @path references to files in the user message text
+var attachmentReference = regexp.MustCompile(`(?:^|\s)@(\S+)`)
+
+// attachmentTrailingPunctuation is trimmed from the @path references (e.g. "see @main.go.")
+const attachmentTrailingPunctuation = ".,;:!?)]}'\""
+
+// withAttachments loads the files referenced in the user message text with the @path syntax.
+// References that don't resolve to an existing regular file (e.g. "ask @alice") are left as plain text.
+// Text files can be provided to any model, images and PDFs require a model that supports vision.
+func (a *Ai) withAttachments(message api.Message) (api.Message, error) {
+	if message.Type != api.MessageTypeUser {
+		return message, nil
+	}
+	for _, match := range attachmentReference.FindAllStringSubmatch(message.Text, -1) {
+		path := strings.TrimRight(match[1], attachmentTrailingPunctuation)
+		if info, err := config.FileSystem.Stat(path); path == "" || err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		attachment, err := loadAttachment(path)
+		if err != nil {
+			return message, err
+		}
+		if !attachment.IsText() && !a.inferenceProvider.SupportsVision() {
+			return message, fmt.Errorf("the selected model (%s) does not support %s attachments (%s), only text files can be attached",
+				a.inferenceProvider.Attributes().Name(), attachment.MimeType, attachment.Name)
+		}
+		message.Attachments = append(message.Attachments, attachment)
+	}
+	return message, nil
+}
+
+func loadAttachment(path string) (api.Attachment, error) {
+	info, err := config.FileSystem.Stat(path)
+	if err != nil {
+		return api.Attachment{}, fmt.Errorf("failed to attach %s: %w", path, err)
+	}
+	if info.Size() > maxAttachmentSize {
+		return api.Attachment{}, fmt.Errorf("failed to attach %s: file exceeds the maximum size of %d bytes", path, maxAttachmentSize)
+	}
+	data, err := afero.ReadFile(config.FileSystem, path)
+	if err != nil {
+		return api.Attachment{}, fmt.Errorf("failed to attach %s: %w", path, err)
+	}
+	attachment := api.Attachment{Name: path, MimeType: mimeType(path, data), Data: data}
+	if !attachment.IsText() && !attachment.IsImage() && attachment.MimeType != "application/pdf" {
+		return api.Attachment{}, fmt.Errorf("failed to attach %s: unsupported file type %s", path, attachment.MimeType)
+	}
+	return attachment, nil
+}
+
+func mimeType(path string, data []byte) string {
+	mimeType := mime.TypeByExtension(filepath.Ext(path))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		return mediaType
+	}
+	return mimeType
+}
+
+// toSchemaUserMessage converts a user message and its attachments to an eino message.
+// Text attachments are provided inline, images and PDFs are provided as multi-content parts (RFC-2397 data URLs).
+func toSchemaUserMessage(message api.Message) *schema.Message {
+	text := strings.Builder{}
+	text.WriteString(message.Text)
+	var parts []schema.ChatMessagePart
+	for _, attachment := range message.Attachments {
+		dataUrl := fmt.Sprintf("data:%s;base64,%s", attachment.MimeType, base64.StdEncoding.EncodeToString(attachment.Data))
+		switch {
+		case attachment.IsText():
+			text.WriteString(fmt.Sprintf("\n<attachment name=\"%s\">\n%s\n</attachment>", attachment.Name, string(attachment.Data)))
+		case attachment.IsImage():
+			parts = append(parts, schema.ChatMessagePart{
+				Type:     schema.ChatMessagePartTypeImageURL,
+				ImageURL: &schema.ChatMessageImageURL{URL: dataUrl, MIMEType: attachment.MimeType},
+			})
+		default:
+			parts = append(parts, schema.ChatMessagePart{
+				Type:    schema.ChatMessagePartTypeFileURL,
+				FileURL: &schema.ChatMessageFileURL{URL: dataUrl, MIMEType: attachment.MimeType, Name: filepath.Base(attachment.Name)},
+			})
+		}
+	}
+	if len(parts) == 0 {
+		return schema.UserMessage(text.String())
+	}
+	return &schema.Message{
+		Role: schema.User,
+		MultiContent: append([]schema.ChatMessagePart{
+			{Type: schema.ChatMessagePartTypeText, Text: text.String()},
+		}, parts...),
+	}
+}
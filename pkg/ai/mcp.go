@@ -25,8 +25,11 @@ type ToolsProviderMcpClient struct {
 // Adaptation of https://github.com/cloudwego/eino-ext/blob/4a4306a8bf2cdae95b3e95bbe05b40fda0475fc2/components/tool/mcp/mcp.go
 // to deal with https://github.com/cloudwego/eino-ext/issues/436
 type mcpTool struct {
-	toolInfo *schema.ToolInfo
-	cli      *ToolsProviderMcpClient
+	// name is the original name of the tool in the MCP server (toolInfo.Name is prefixed with the toolset name)
+	name        string
+	toolInfo    *schema.ToolInfo
+	annotations *mcp.ToolAnnotations
+	cli         *ToolsProviderMcpClient
 }
 
 var _ ToolManagerTool = &mcpTool{}
@@ -39,13 +42,32 @@ func (m *mcpTool) ToolInfo() *schema.ToolInfo {
 	return m.toolInfo
 }
 
+// ReadOnly follows the MCP specification defaults, tools are not read-only unless annotated as such
+func (m *mcpTool) ReadOnly() bool {
+	return m.annotations != nil && m.annotations.ReadOnlyHint
+}
+
+// Destructive follows the MCP specification defaults, tools are destructive unless annotated as read-only or non-destructive
+func (m *mcpTool) Destructive() bool {
+	if m.annotations == nil {
+		return true
+	}
+	if m.annotations.ReadOnlyHint {
+		return false
+	}
+	return m.annotations.DestructiveHint == nil || *m.annotations.DestructiveHint
+}
+
 func (m *mcpTool) Info(_ context.Context) (*schema.ToolInfo, error) {
 	return m.toolInfo, nil
 }
 
 func (m *mcpTool) InvokableRun(ctx context.Context, argumentsInJSON string, _ ...tool.Option) (string, error) {
+	if violations := validateArguments(m.toolInfo, argumentsInJSON); violations != "" {
+		return violations, nil
+	}
 	result, err := m.cli.CallTool(ctx, &mcp.CallToolParams{
-		Name:      m.toolInfo.Name,
+		Name:      m.name,
 		Arguments: json.RawMessage(argumentsInJSON),
 	})
 	if err != nil {
@@ -78,12 +100,15 @@ func toMcpTools(ctx context.Context, cli *ToolsProviderMcpClient) ([]ToolManager
 		}
 
 		ret = append(ret, &mcpTool{
+			name: t.Name,
 			toolInfo: &schema.ToolInfo{
-				Name:        t.Name,
+				// Tools are namespaced by toolset (same as native tools) so that tools of different MCP servers don't collide
+				Name:        cli.Attributes().Name() + "_" + t.Name,
 				Desc:        t.Description,
 				ParamsOneOf: schema.NewParamsOneOfByJSONSchema(inputSchema),
 			},
-			cli: cli,
+			annotations: t.Annotations,
+			cli:         cli,
 		})
 	}
 
@@ -3,6 +3,8 @@ package ai
 import (
 	"context"
 	"reflect"
+	"slices"
+	"sync"
 
 	"github.com/charmbracelet/log"
 	"github.com/cloudwego/eino/callbacks"
@@ -26,16 +28,24 @@ const (
 type ReActAgent struct {
 	*react.Agent
 	ai *Ai
+	// toolCallIDs are the IDs of the tool calls of the current step that have not been appended to the session yet,
+	// in the order requested by the model
+	toolCallIDs []string
+	// toolMessages are the results of the tool calls that finished before the ones preceding them (tool call ID -> message)
+	toolMessages      map[string]api.Message
+	toolMessagesMutex sync.Mutex
 }
 
 func NewReActAgent(ctx context.Context, ai *Ai) (agent *ReActAgent, err error) {
 	agent = &ReActAgent{ai: ai}
 	agent.Agent, err = react.NewAgent(ctx, &react.AgentConfig{
 		ToolCallingModel: ai.llm,
-		MaxStep:          DefaultMaxSteps,
+		MaxStep:          ai.maxSteps,
 		ToolsConfig: compose.ToolsNodeConfig{
-			Tools:               ai.toolManager.EnabledTools(),
-			ExecuteSequentially: true,
+			// Only the built-in tools are declared in the graph, toolset tools are resolved by the unknownToolHandler
+			// so that toolsets can be enabled and disabled while the agent is running
+			Tools:               ai.toolManager.BuiltInTools(),
+			ExecuteSequentially: ai.agentsParameters.ParallelToolCalls == nil || !*ai.agentsParameters.ParallelToolCalls,
 			UnknownToolsHandler: agent.unknownToolHandler,
 		},
 	})
@@ -47,12 +57,13 @@ func NewReActAgent(ctx context.Context, ai *Ai) (agent *ReActAgent, err error) {
 
 // unknownToolHandler is called when the model tries to call a tool that is not in the list of available toolManager.
 // This is a workaround because graph tool declarations are immutable after the graph is created and compiled.
-// The model might be actually calling a tool that was enabled after the graph was created (hence not unknown).
+// The model might be actually calling a tool that was enabled after the graph was created (hence not unknown),
+// or a tool that was disabled after the graph was created (hence no longer available).
 //
 // The only issue is that standard callbacks are not called for unknown toolManager, so we manually call them here.
-func (r *ReActAgent) unknownToolHandler(ctx context.Context, name, input string) (string, error) {
+func (r *ReActAgent) unknownToolHandler(ctx context.Context, name, input string) (result string, err error) {
 	r.OnToolCallStart(ctx, &callbacks.RunInfo{Name: name}, &tool.CallbackInput{ArgumentsInJSON: input})
-	defer r.OnToolCallEnd(ctx, &callbacks.RunInfo{Name: name}, &tool.CallbackOutput{})
+	defer func() { r.OnToolCallEnd(ctx, &callbacks.RunInfo{Name: name}, &tool.CallbackOutput{Response: result}) }()
 	return r.ai.toolManager.InvokeTool(ctx, name, input)
 }
 
@@ -66,6 +77,19 @@ func (r *ReActAgent) OnChatModelStart(ctx context.Context, runInfo *callbacks.Ru
 	return ctx
 }
 
+// OnToolsNodeStart records the order of the tool calls requested by the model so that the tool messages are appended
+// to the session in the same order, even if the tools are called concurrently.
+func (r *ReActAgent) OnToolsNodeStart(ctx context.Context, _ *callbacks.RunInfo, input *schema.Message) context.Context {
+	r.toolMessagesMutex.Lock()
+	defer r.toolMessagesMutex.Unlock()
+	r.toolCallIDs = make([]string, 0, len(input.ToolCalls))
+	for _, toolCall := range input.ToolCalls {
+		r.toolCallIDs = append(r.toolCallIDs, toolCall.ID)
+	}
+	r.toolMessages = make(map[string]api.Message)
+	return ctx
+}
+
 func (r *ReActAgent) OnToolCallStart(ctx context.Context, info *callbacks.RunInfo, input *tool.CallbackInput) context.Context {
 	log.Debug("calling tool", "name", info.Name, "input", input.ArgumentsInJSON)
 	return ctx
@@ -73,10 +97,30 @@ func (r *ReActAgent) OnToolCallStart(ctx context.Context, info *callbacks.RunInf
 
 func (r *ReActAgent) OnToolCallEnd(ctx context.Context, info *callbacks.RunInfo, output *tool.CallbackOutput) context.Context {
 	log.Debug("called tool", "name", info.Name, "response", output.Response)
-	r.ai.appendMessage(api.NewToolMessage(output.Response, info.Name))
+	r.appendToolMessage(compose.GetToolCallID(ctx), api.NewToolMessage(output.Response, info.Name))
 	return ctx
 }
 
+// appendToolMessage appends the tool message to the session once the messages of the preceding tool calls are appended.
+func (r *ReActAgent) appendToolMessage(toolCallID string, message api.Message) {
+	r.toolMessagesMutex.Lock()
+	defer r.toolMessagesMutex.Unlock()
+	if toolCallID == "" || !slices.Contains(r.toolCallIDs, toolCallID) {
+		r.ai.appendMessage(message)
+		return
+	}
+	r.toolMessages[toolCallID] = message
+	for len(r.toolCallIDs) > 0 {
+		next, finished := r.toolMessages[r.toolCallIDs[0]]
+		if !finished {
+			break
+		}
+		r.ai.appendMessage(next)
+		delete(r.toolMessages, r.toolCallIDs[0])
+		r.toolCallIDs = r.toolCallIDs[1:]
+	}
+}
+
 func (r *ReActAgent) Stream(ctx context.Context) (*schema.StreamReader[*schema.Message], error) {
 	return r.Agent.Stream(
 		ctx,
@@ -85,6 +129,9 @@ func (r *ReActAgent) Stream(ctx context.Context) (*schema.StreamReader[*schema.M
 			callbackutils.NewHandlerHelper().ChatModel(&callbackutils.ModelCallbackHandler{
 				OnStart: r.OnChatModelStart,
 			}).Handler(),
+			callbackutils.NewHandlerHelper().ToolsNode(&callbackutils.ToolsNodeCallbackHandlers{
+				OnStart: r.OnToolsNodeStart,
+			}).Handler(),
 			callbackutils.NewHandlerHelper().Tool(&callbackutils.ToolCallbackHandler{
 				OnStart: r.OnToolCallStart,
 				OnEnd:   r.OnToolCallEnd,
@@ -8,6 +8,8 @@ type Session struct {
 	messageInProgress api.Message
 	error             error
 	running           bool
+	// pendingToolCallApproval is the destructive tool call waiting for the user approval
+	pendingToolCallApproval *api.ToolCallApproval
 }
 
 var _ api.Session = (*Session)(nil)
@@ -35,3 +37,7 @@ func (s *Session) SystemPrompt() api.Message {
 func (s *Session) IsRunning() bool {
 	return s.running
 }
+
+func (s *Session) PendingToolCallApproval() *api.ToolCallApproval {
+	return s.pendingToolCallApproval
+}
@@ -0,0 +1,179 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/manusa/ai-cli/pkg/api"
+)
+
+const subAgentToolName = "subagent_run"
+
+// subAgentTool is a built-in tool that delegates a task to a child Ai with a fresh session.
+// The child Ai only has access to a restricted set of toolsets and returns its final answer to the parent.
+type subAgentTool struct {
+	ai       *Ai
+	toolInfo *schema.ToolInfo
+}
+
+var _ ToolManagerTool = &subAgentTool{}
+
+func newSubAgentTool(ai *Ai) *subAgentTool {
+	toolsetNames := make([]string, 0, len(ai.toolsProviders))
+	for _, t := range ai.toolsProviders {
+		toolsetNames = append(toolsetNames, t.Attributes().Name())
+	}
+	return &subAgentTool{
+		ai: ai,
+		toolInfo: &schema.ToolInfo{
+			Name: subAgentToolName,
+			Desc: "Delegate a self-contained task to a sub-agent with a fresh context.\n" +
+				"Use it for complex tasks that can be split into independent steps (e.g. gathering and comparing information from several sources).\n" +
+				"The sub-agent only returns its final answer.",
+			ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+				"task": {
+					Type:     schema.String,
+					Desc:     "A complete description of the task for the sub-agent, including all the context it needs and the expected answer.",
+					Required: true,
+				},
+				"toolset_names": {
+					Type: schema.String,
+					Desc: "The name or names of the toolsets the sub-agent can use, separated by commas.\n" +
+						"Valid toolset names: " + strings.Join(toolsetNames, ", "),
+					Required: true,
+				},
+				"max_steps": {
+					Type: schema.Integer,
+					Desc: fmt.Sprintf("The maximum number of steps the sub-agent can perform (up to %d).", *ai.agentsParameters.MaxSteps),
+				},
+			}),
+		},
+	}
+}
+
+func (s *subAgentTool) ToolsProvider() api.ToolsProvider {
+	return nil
+}
+
+func (s *subAgentTool) ToolInfo() *schema.ToolInfo {
+	return s.toolInfo
+}
+
+func (s *subAgentTool) ReadOnly() bool {
+	return true // The tools called by the sub-agent are restricted by their own toolset parameters
+}
+
+func (s *subAgentTool) Destructive() bool {
+	return false // The tools called by the sub-agent require their own approval
+}
+
+func (s *subAgentTool) Info(_ context.Context) (*schema.ToolInfo, error) {
+	return s.toolInfo, nil
+}
+
+func (s *subAgentTool) InvokableRun(ctx context.Context, argumentsInJSON string, _ ...tool.Option) (string, error) {
+	args := struct {
+		Task         string `json:"task"`
+		ToolsetNames string `json:"toolset_names"`
+		MaxSteps     int    `json:"max_steps"`
+	}{}
+	if argumentsInJSON != "" {
+		if err := json.Unmarshal([]byte(argumentsInJSON), &args); err != nil {
+			return "", err
+		}
+	}
+	if strings.TrimSpace(args.Task) == "" {
+		return "A task is required to run a sub-agent.", nil
+	}
+	toolsetNames, invalidMessage := parseToolsetNames(args.ToolsetNames, s.ai.toolsProviders)
+	if invalidMessage != "" {
+		return invalidMessage, nil
+	}
+	maxSteps := *s.ai.agentsParameters.MaxSteps
+	if args.MaxSteps > 0 && args.MaxSteps < maxSteps {
+		maxSteps = args.MaxSteps
+	}
+	// Callback handlers are inherited through the context, the parent's handlers must not observe the sub-agent
+	ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{Name: subAgentToolName})
+	subAgent, err := s.ai.newSubAgent(ctx, toolsetNames, maxSteps)
+	if err != nil {
+		return "", err
+	}
+	subAgent.prompt(ctx, api.NewUserMessage(args.Task))
+	if subAgent.session.error != nil {
+		return fmt.Sprintf("The sub-agent failed: %s", subAgent.session.error.Error()), nil
+	}
+	messages := subAgent.Session().Messages()
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Type == api.MessageTypeAssistant {
+			return messages[i].Text, nil
+		}
+	}
+	return "The sub-agent finished without providing an answer.", nil
+}
+
+// newSubAgent creates a child Ai with a fresh session that can only use the tools of the provided toolsets.
+// The toolsets are enabled from the start so that the sub-agent can focus on the delegated task.
+func (a *Ai) newSubAgent(ctx context.Context, toolsetNames []string, maxSteps int) (subAgent *Ai, err error) {
+	subAgent = &Ai{
+		inferenceProvider: a.inferenceProvider,
+		session:           &Session{systemPrompt: a.session.SystemPrompt()},
+		maxSteps:          maxSteps,
+		agentsParameters:  a.agentsParameters,
+		toolsParameters:   a.toolsParameters,
+		parent:            a,
+		depth:             a.depth + 1,
+	}
+	subAgent.llm, err = NewDynamicToolCallingChatModel(a.inferenceProvider.GetInference(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inference: %w", err)
+	}
+	for _, toolsProvider := range a.toolsProviders {
+		if slices.Contains(toolsetNames, toolsProvider.Attributes().Name()) {
+			subAgent.toolsProviders = append(subAgent.toolsProviders, toolsProvider)
+		}
+	}
+	tools := make([]ToolManagerTool, 0)
+	for _, availableTool := range a.toolManager.availableTools {
+		if slices.Contains(subAgent.toolsProviders, availableTool.ToolsProvider()) {
+			tools = append(tools, availableTool)
+		}
+	}
+	subAgent.toolManager = subAgent.newToolManager(tools)
+	if len(toolsetNames) > 0 {
+		_, _ = subAgent.toolManager.toolsetEnable(map[string]interface{}{"toolset_names": strings.Join(toolsetNames, ",")})
+	}
+	return subAgent, nil
+}
+
+// newToolManager creates the ToolManager for the agent including the sub-agent tool if the nesting depth allows it.
+func (a *Ai) newToolManager(tools []ToolManagerTool) *ToolManager {
+	toolManager := NewToolManager(a.toolsProviders, tools)
+	if a.toolsParameters != nil {
+		toolManager.restrictTools(a.toolsParameters)
+	}
+	if a.parent != nil {
+		// Sub-agents share the toolset concurrency limits with the parent agent
+		toolManager.toolsetConcurrency = a.parent.toolManager.toolsetConcurrency
+		toolManager.toolOutputs = a.parent.toolManager.toolOutputs
+	} else if a.toolsParameters != nil {
+		toolManager.limitConcurrency(a.toolsParameters)
+	}
+	if a.toolsParameters != nil {
+		toolManager.limitExecution(a.toolsParameters)
+	}
+	toolManager.autoEnableToolsets = a.agentsParameters.AutoEnableToolsets != nil && *a.agentsParameters.AutoEnableToolsets
+	if a.agentsParameters.RequireApproval != nil && *a.agentsParameters.RequireApproval {
+		toolManager.approveToolCall = a.approveToolCall
+	}
+	if a.agentsParameters.MaxDepth != nil && a.depth < *a.agentsParameters.MaxDepth {
+		toolManager.builtInTools = append(toolManager.builtInTools, newSubAgentTool(a))
+	}
+	return toolManager
+}
@@ -3,7 +3,10 @@ package ai
 import (
 	"context"
 	"fmt"
+	"path"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/schema"
@@ -15,12 +18,32 @@ type ToolManagerTool interface {
 	tool.BaseTool
 	ToolsProvider() api.ToolsProvider
 	ToolInfo() *schema.ToolInfo
+	// ReadOnly returns true if the tool doesn't modify its environment
+	ReadOnly() bool
+	// Destructive returns true if the tool may perform destructive updates
+	Destructive() bool
 }
 
 type ToolManager struct {
+	toolsProviders []api.ToolsProvider
 	availableTools []ToolManagerTool
 	enabledTools   map[string]ToolManagerTool
-	enableTool     ToolManagerTool
+	// enabledToolsMutex guards enabledTools, tools might be called (and toolsets enabled) concurrently
+	enabledToolsMutex sync.RWMutex
+	// toolsetConcurrency limits the number of concurrent calls to the tools of a toolset (semaphore per toolset)
+	toolsetConcurrency map[api.ToolsProvider]chan struct{}
+	// toolLimits are the timeout and maximum output size of each tool (tool name -> limits)
+	toolLimits map[string]toolLimits
+	// toolOutputs stores the tool results that exceed the maximum output size so that the model can page through them
+	toolOutputs *toolOutputs
+	// restrictedTools are the tools removed from availableTools by the toolset parameters (tool name -> reason)
+	restrictedTools map[string]string
+	// builtInTools are always enabled and don't belong to any toolset (e.g. toolset_enable)
+	builtInTools []ToolManagerTool
+	// autoEnableToolsets enables the owning toolset when the model calls a tool of a disabled toolset
+	autoEnableToolsets bool
+	// approveToolCall (if set) is called before invoking a destructive tool, the tool is invoked only if approved
+	approveToolCall func(ctx context.Context, toolName, arguments string) (approved bool, reason string)
 }
 
 func NewToolManager(toolsProviders []api.ToolsProvider, availableTools []ToolManagerTool) *ToolManager {
@@ -36,10 +59,12 @@ func NewToolManager(toolsProviders []api.ToolsProvider, availableTools []ToolMan
 	}
 	toolNameParameter.WriteString("\n</toolsets>")
 	toolManager := &ToolManager{
-		availableTools: availableTools,
-		enabledTools:   make(map[string]ToolManagerTool),
+		toolsProviders:  toolsProviders,
+		availableTools:  availableTools,
+		enabledTools:    make(map[string]ToolManagerTool),
+		restrictedTools: make(map[string]string),
 	}
-	toolManager.enableTool = &invokableTool{
+	toolManager.builtInTools = append(toolManager.builtInTools, &invokableTool{
 		toolInfo: &schema.ToolInfo{
 			Name: "toolset_enable",
 			Desc: "Enable a toolset for the current session.",
@@ -48,16 +73,44 @@ func NewToolManager(toolsProviders []api.ToolsProvider, availableTools []ToolMan
 					Type:     schema.String,
 					Desc:     toolNameParameter.String(),
 					Required: true,
-					Enum:     toolsetNames,
 				},
 			}),
 		},
 		function: toolManager.toolsetEnable,
-	}
+	}, &invokableTool{
+		toolInfo: &schema.ToolInfo{
+			Name: "toolset_disable",
+			Desc: "Disable a toolset that is no longer needed for the current session.",
+			ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+				"toolset_names": {
+					Type: schema.String,
+					Desc: "The name or names of the toolsets to disable.\n" +
+						"You can disable multiple toolsets separating their names with commas.\n" +
+						"Valid toolset names: " + strings.Join(toolsetNames, ", "),
+					Required: true,
+				},
+			}),
+		},
+		function: toolManager.toolsetDisable,
+	}, &invokableTool{
+		toolInfo: &schema.ToolInfo{
+			Name:        "toolset_list",
+			Desc:        "List the available toolsets, whether they are enabled, and the number of tools they provide.",
+			ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{}),
+		},
+		function: toolManager.toolsetList,
+	})
 	return toolManager
 }
 
+// Close removes the stored tool outputs
+func (t *ToolManager) Close() {
+	t.toolOutputs.close()
+}
+
 func (t *ToolManager) ToolEnabledCount() int {
+	t.enabledToolsMutex.RLock()
+	defer t.enabledToolsMutex.RUnlock()
 	return len(t.enabledTools)
 }
 
@@ -66,37 +119,267 @@ func (t *ToolManager) ToolCount() int {
 }
 
 func (t *ToolManager) EnabledToolsReset() {
+	t.enabledToolsMutex.Lock()
+	defer t.enabledToolsMutex.Unlock()
 	t.enabledTools = make(map[string]ToolManagerTool)
 }
 
+// EnabledTools returns the tools that should be provided to the model, in a stable order.
+// Tools from disabled toolsets are excluded so that the model payload shrinks once a toolset is disabled.
 func (t *ToolManager) EnabledTools() []tool.BaseTool {
-	ret := make([]tool.BaseTool, 0, len(t.enabledTools))
-	for _, enabledTool := range t.enabledTools {
-		ret = append(ret, enabledTool)
+	t.enabledToolsMutex.RLock()
+	defer t.enabledToolsMutex.RUnlock()
+	ret := make([]tool.BaseTool, 0, len(t.enabledTools)+len(t.builtInTools))
+	for _, availableTool := range t.availableTools {
+		if _, enabled := t.enabledTools[availableTool.ToolInfo().Name]; enabled {
+			ret = append(ret, availableTool)
+		}
+	}
+	for _, builtInTool := range t.builtInTools {
+		ret = append(ret, builtInTool) // Always include the built-in tools
+	}
+	return ret
+}
+
+// BuiltInTools returns the tools that are always enabled.
+func (t *ToolManager) BuiltInTools() []tool.BaseTool {
+	ret := make([]tool.BaseTool, 0, len(t.builtInTools))
+	for _, builtInTool := range t.builtInTools {
+		ret = append(ret, builtInTool)
 	}
-	ret = append(ret, t.enableTool) // Always include the enable tool
 	return ret
 }
 
+// InvokeTool invokes an enabled tool by name.
+// If the tool is not enabled, the result points the model to the toolset it belongs to (or to the closest matches),
+// unless autoEnableToolsets is set, in which case the owning toolset is enabled and the tool invoked right away.
 func (t *ToolManager) InvokeTool(ctx context.Context, name, input string) (string, error) {
-	if enabledTool, exists := t.enabledTools[name]; exists {
-		return enabledTool.InvokableRun(ctx, input)
+	if enabledTool, exists := t.enabledTool(name); exists {
+		return t.invoke(ctx, enabledTool, input)
+	}
+	if reason, restricted := t.restrictedTools[name]; restricted {
+		return fmt.Sprintf("Tool '%s' is not available because %s.", name, reason), nil
+	}
+	matches := t.findTools(name)
+	if len(matches) > 0 && normalizeToolName(matches[0].ToolInfo().Name) == normalizeToolName(name) {
+		return t.invokeDisabledTool(ctx, name, matches[0], input)
+	}
+	if len(matches) == 0 {
+		return fmt.Sprintf("Tool '%s' not found.", name), nil
+	}
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("Tool '%s' not found. Did you mean one of the following tools?\n", name))
+	for _, match := range matches {
+		sb.WriteString(fmt.Sprintf("- '%s'", match.ToolInfo().Name))
+		if match.ToolsProvider() != nil {
+			toolsetName := match.ToolsProvider().Attributes().Name()
+			if _, enabled := t.enabledTool(match.ToolInfo().Name); enabled {
+				sb.WriteString(fmt.Sprintf(" (toolset '%s')", toolsetName))
+			} else {
+				sb.WriteString(fmt.Sprintf(" (toolset '%s', not enabled, enable it by calling the 'toolset_enable' tool first)", toolsetName))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+func (t *ToolManager) enabledTool(name string) (ToolManagerTool, bool) {
+	t.enabledToolsMutex.RLock()
+	defer t.enabledToolsMutex.RUnlock()
+	enabledTool, exists := t.enabledTools[name]
+	return enabledTool, exists
+}
+
+func (t *ToolManager) invokeDisabledTool(ctx context.Context, name string, match ToolManagerTool, input string) (string, error) {
+	toolName := match.ToolInfo().Name
+	if _, enabled := t.enabledTool(toolName); enabled || match.ToolsProvider() == nil {
+		// The model misspelled the name of a tool that's already available
+		return t.invoke(ctx, match, input)
+	}
+	toolsetName := match.ToolsProvider().Attributes().Name()
+	if !t.autoEnableToolsets {
+		return fmt.Sprintf("Tool '%s' belongs to the '%s' toolset, which is not enabled. "+
+			"Enable it by calling the 'toolset_enable' tool with toolset_names '%s', then call '%s' again.",
+			name, toolsetName, toolsetName, toolName), nil
 	}
-	// TODO: Report the toolset the tool belongs to, and suggest enabling it
-	//if _, exists := t.availableTools[name]; exists {
-	//	return fmt.Sprintf("Tool '%s' is not enabled. You can enable it by calling the 'tool_enable' tool first.", name), nil
-	//}
-	return fmt.Sprintf("Tool '%s' not found.", name), nil
+	_, _ = t.toolsetEnable(map[string]interface{}{"toolset_names": toolsetName})
+	return t.invoke(ctx, match, input)
+}
+
+// restrictTools removes the tools that are not allowed by the parameters of their toolset (allowed and denied tools,
+// ReadOnly, and DisableDestructive).
+// The tool hints (MCP ToolAnnotations for MCP servers) are used, so the restrictions apply to any toolset
+// regardless of the toolset (MCP server) supporting its own read-only or non-destructive mode.
+func (t *ToolManager) restrictTools(toolsParameters func(toolsetName string) api.ToolsParameters) {
+	allowedTools := make([]ToolManagerTool, 0, len(t.availableTools))
+	for _, availableTool := range t.availableTools {
+		if availableTool.ToolsProvider() == nil {
+			allowedTools = append(allowedTools, availableTool)
+			continue
+		}
+		toolsetName := availableTool.ToolsProvider().Attributes().Name()
+		parameters := toolsParameters(toolsetName)
+		deniedBy := matchesToolPattern(parameters.DeniedTools, toolsetName, availableTool.ToolInfo().Name)
+		allowedBy := matchesToolPattern(parameters.AllowedTools, toolsetName, availableTool.ToolInfo().Name)
+		switch {
+		case deniedBy != "":
+			t.restrictedTools[availableTool.ToolInfo().Name] = fmt.Sprintf("the tool is denied by the '%s' pattern", deniedBy)
+		case len(parameters.AllowedTools) > 0 && allowedBy == "":
+			t.restrictedTools[availableTool.ToolInfo().Name] = fmt.Sprintf("the tool does not match any of the allowed tools patterns (%s)",
+				strings.Join(parameters.AllowedTools, ", "))
+		case parameters.ReadOnly != nil && *parameters.ReadOnly && !availableTool.ReadOnly():
+			t.restrictedTools[availableTool.ToolInfo().Name] = fmt.Sprintf("the '%s' toolset is read-only", toolsetName)
+		case parameters.DisableDestructive != nil && *parameters.DisableDestructive && availableTool.Destructive():
+			t.restrictedTools[availableTool.ToolInfo().Name] = fmt.Sprintf("the '%s' toolset does not allow destructive tools", toolsetName)
+		default:
+			allowedTools = append(allowedTools, availableTool)
+		}
+	}
+	t.availableTools = allowedTools
+}
+
+// limitConcurrency sets the maximum number of concurrent calls for the toolsets with a MaxConcurrency parameter.
+func (t *ToolManager) limitConcurrency(toolsParameters func(toolsetName string) api.ToolsParameters) {
+	t.toolsetConcurrency = make(map[api.ToolsProvider]chan struct{})
+	for _, toolsProvider := range t.toolsProviders {
+		parameters := toolsParameters(toolsProvider.Attributes().Name())
+		if parameters.MaxConcurrency != nil && *parameters.MaxConcurrency > 0 {
+			t.toolsetConcurrency[toolsProvider] = make(chan struct{}, *parameters.MaxConcurrency)
+		}
+	}
+}
+
+// matchesToolPattern returns the first glob pattern matching the tool name, or an empty string if none matches.
+// Patterns are matched against the tool name both with and without the toolset prefix (e.g. fs_file_list and file_list).
+func matchesToolPattern(patterns []string, toolsetName, toolName string) string {
+	for _, pattern := range patterns {
+		for _, name := range []string{toolName, strings.TrimPrefix(toolName, toolsetName+"_")} {
+			if matched, _ := path.Match(pattern, name); matched {
+				return pattern
+			}
+		}
+	}
+	return ""
+}
+
+// invoke invokes the tool, destructive tools are invoked only if the call is approved.
+// The denial of a call (and its reason) is reported in the result.
+func (t *ToolManager) invoke(ctx context.Context, managedTool ToolManagerTool, input string) (string, error) {
+	toolName := managedTool.ToolInfo().Name
+	if managedTool.Destructive() && t.approveToolCall != nil {
+		if approved, reason := t.approveToolCall(ctx, toolName, input); !approved {
+			result := fmt.Sprintf("The user denied the call to the '%s' tool.", toolName)
+			if reason != "" {
+				result += " Reason: " + reason
+			}
+			return result, nil
+		}
+	}
+	if semaphore, limited := t.toolsetConcurrency[managedTool.ToolsProvider()]; limited {
+		select {
+		case semaphore <- struct{}{}:
+			defer func() { <-semaphore }()
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	return t.invokeWithLimits(ctx, managedTool, input)
+}
+
+// maxToolSuggestions is the maximum number of similar tools reported when a tool is not found
+const maxToolSuggestions = 5
+
+// findTools returns the available tools (both enabled and disabled) whose name is similar to the provided name.
+// The best match is returned first.
+func (t *ToolManager) findTools(name string) []ToolManagerTool {
+	type scoredTool struct {
+		tool  ToolManagerTool
+		score int
+	}
+	normalizedName := normalizeToolName(name)
+	var scored []scoredTool
+	for _, candidate := range append(slices.Clone(t.availableTools), t.builtInTools...) {
+		candidateName := normalizeToolName(candidate.ToolInfo().Name)
+		score := levenshtein(normalizedName, candidateName)
+		// The model might call the tool without the toolset (provider) prefix or with a partial name
+		if len(normalizedName) >= 3 && strings.Contains(candidateName, normalizedName) {
+			score = min(score, 1)
+		}
+		if score <= max(2, len(candidateName)/4) {
+			scored = append(scored, scoredTool{tool: candidate, score: score})
+		}
+	}
+	slices.SortStableFunc(scored, func(a, b scoredTool) int { return a.score - b.score })
+	matches := make([]ToolManagerTool, 0, min(len(scored), maxToolSuggestions))
+	for i := 0; i < len(scored) && i < maxToolSuggestions; i++ {
+		matches = append(matches, scored[i].tool)
+	}
+	return matches
+}
+
+func normalizeToolName(name string) string {
+	return strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// parseToolsetNames splits the comma-separated toolset names and validates them against the provided toolsets.
+// The returned message (if any) explains the problem to the model and should be returned as the tool result.
+func parseToolsetNames(toolsetNamesArg string, toolsProviders []api.ToolsProvider) (toolsetNames []string, invalidMessage string) {
+	validToolsetNames := make([]string, 0, len(toolsProviders))
+	for _, toolsProvider := range toolsProviders {
+		validToolsetNames = append(validToolsetNames, toolsProvider.Attributes().Name())
+	}
+	var invalidToolsetNames []string
+	for _, toolsetName := range strings.Split(toolsetNamesArg, ",") {
+		if toolsetName = strings.TrimSpace(toolsetName); toolsetName == "" {
+			continue
+		} else if slices.Contains(validToolsetNames, toolsetName) {
+			toolsetNames = append(toolsetNames, toolsetName)
+		} else {
+			invalidToolsetNames = append(invalidToolsetNames, toolsetName)
+		}
+	}
+	if len(invalidToolsetNames) > 0 {
+		return nil, fmt.Sprintf("Invalid toolset names: %s. Valid toolset names: %s",
+			strings.Join(invalidToolsetNames, ", "), strings.Join(validToolsetNames, ", "))
+	}
+	if len(toolsetNames) == 0 {
+		return nil, "At least one toolset name is required. Valid toolset names: " + strings.Join(validToolsetNames, ", ")
+	}
+	return toolsetNames, ""
 }
 
 func (t *ToolManager) toolsetEnable(args map[string]interface{}) (string, error) {
-	toolsetNames, ok := args["toolset_names"].(string)
-	if !ok {
-		return "Invalid toolset names.", nil
+	toolsetNamesArg, _ := args["toolset_names"].(string)
+	toolsetNames, invalidMessage := parseToolsetNames(toolsetNamesArg, t.toolsProviders)
+	if invalidMessage != "" {
+		return invalidMessage, nil
 	}
-	sb := strings.Builder{}
-	for _, toolsetName := range strings.Split(toolsetNames, ",") {
-		toolsetName = strings.TrimSpace(toolsetName)
+	t.enabledToolsMutex.Lock()
+	defer t.enabledToolsMutex.Unlock()
+	messages := make([]string, 0, len(toolsetNames))
+	for _, toolsetName := range toolsetNames {
 		for _, availableTool := range t.availableTools {
 			// The built-in enable tool does not have a provider
 			if availableTool.ToolsProvider() != nil && availableTool.ToolsProvider().Attributes().Name() != toolsetName {
@@ -110,7 +393,50 @@ func (t *ToolManager) toolsetEnable(args map[string]interface{}) (string, error)
 			}
 			t.enabledTools[toolName] = availableTool
 		}
-		sb.WriteString(fmt.Sprintf("Toolset '%s' enabled.", toolsetName))
+		messages = append(messages, fmt.Sprintf("Toolset '%s' enabled.", toolsetName))
+	}
+	return strings.Join(messages, "\n"), nil
+}
+
+func (t *ToolManager) toolsetDisable(args map[string]interface{}) (string, error) {
+	toolsetNamesArg, _ := args["toolset_names"].(string)
+	toolsetNames, invalidMessage := parseToolsetNames(toolsetNamesArg, t.toolsProviders)
+	if invalidMessage != "" {
+		return invalidMessage, nil
+	}
+	t.enabledToolsMutex.Lock()
+	defer t.enabledToolsMutex.Unlock()
+	messages := make([]string, 0, len(toolsetNames))
+	for _, toolsetName := range toolsetNames {
+		for toolName, enabledTool := range t.enabledTools {
+			if enabledTool.ToolsProvider() != nil && enabledTool.ToolsProvider().Attributes().Name() == toolsetName {
+				delete(t.enabledTools, toolName)
+			}
+		}
+		messages = append(messages, fmt.Sprintf("Toolset '%s' disabled.", toolsetName))
+	}
+	return strings.Join(messages, "\n"), nil
+}
+
+func (t *ToolManager) toolsetList(_ map[string]interface{}) (string, error) {
+	t.enabledToolsMutex.RLock()
+	defer t.enabledToolsMutex.RUnlock()
+	sb := strings.Builder{}
+	sb.WriteString("<toolsets>\n")
+	for _, toolsProvider := range t.toolsProviders {
+		toolCount, enabledToolCount := 0, 0
+		for _, availableTool := range t.availableTools {
+			if availableTool.ToolsProvider() != toolsProvider {
+				continue
+			}
+			toolCount++
+			if _, enabled := t.enabledTools[availableTool.ToolInfo().Name]; enabled {
+				enabledToolCount++
+			}
+		}
+		sb.WriteString(fmt.Sprintf(`<toolset name="%s" enabled="%t" tools="%d">%s</toolset>`,
+			toolsProvider.Attributes().Name(), enabledToolCount > 0, toolCount, toolsProvider.Attributes().Description()) + "\n")
 	}
+	sb.WriteString("</toolsets>")
 	return sb.String(), nil
 }
@@ -0,0 +1,236 @@
+package ai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type ToolManagerSuite struct {
+	suite.Suite
+	ToolManager *ToolManager
+}
+
+func (s *ToolManagerSuite) SetupTest() {
+	fs := test.NewToolsProvider("fs", test.WithToolsAvailable())
+	fs.Tools = []*api.Tool{
+		{Name: "read", Description: "Read a file", Function: func(map[string]interface{}) (string, error) { return "content", nil }},
+		{Name: "write", Description: "Write a file", Function: func(map[string]interface{}) (string, error) { return "written", nil }},
+	}
+	fs.FeatureDescription = "File system tools"
+	git := test.NewToolsProvider("git", test.WithToolsAvailable())
+	git.Tools = []*api.Tool{
+		{Name: "status", Description: "Git status", Function: func(map[string]interface{}) (string, error) { return "clean", nil }},
+	}
+	git.FeatureDescription = "Git tools"
+	toolsProviders := []api.ToolsProvider{fs, git}
+	s.ToolManager = NewToolManager(toolsProviders, toInvokableTools(s.T().Context(), toolsProviders))
+}
+
+func (s *ToolManagerSuite) invokeBuiltIn(name, input string) string {
+	for _, builtInTool := range s.ToolManager.builtInTools {
+		if builtInTool.ToolInfo().Name == name {
+			result, err := builtInTool.InvokableRun(s.T().Context(), input)
+			s.Require().NoError(err)
+			return result
+		}
+	}
+	s.FailNow("built-in tool not found", name)
+	return ""
+}
+
+func toolNames(tools []tool.BaseTool) []string {
+	names := make([]string, 0, len(tools))
+	for _, t := range tools {
+		info, _ := t.Info(context.Background())
+		names = append(names, info.Name)
+	}
+	return names
+}
+
+func (s *ToolManagerSuite) TestEnabledToolsInitiallyOnlyBuiltIn() {
+	s.Equal([]string{"toolset_enable", "toolset_disable", "toolset_list"}, toolNames(s.ToolManager.EnabledTools()))
+}
+
+func (s *ToolManagerSuite) TestToolsetEnable() {
+	s.Equal("Toolset 'fs' enabled.", s.invokeBuiltIn("toolset_enable", `{"toolset_names":"fs"}`))
+	s.Run("Adds toolset tools in a stable order", func() {
+		s.Equal([]string{"fs_read", "fs_write", "toolset_enable", "toolset_disable", "toolset_list"}, toolNames(s.ToolManager.EnabledTools()))
+	})
+	s.Run("Enabled tools can be invoked", func() {
+		result, err := s.ToolManager.InvokeTool(s.T().Context(), "fs_read", "{}")
+		s.NoError(err)
+		s.Equal("content", result)
+	})
+}
+
+func (s *ToolManagerSuite) TestToolsetEnableMultiple() {
+	s.Equal("Toolset 'fs' enabled.\nToolset 'git' enabled.", s.invokeBuiltIn("toolset_enable", `{"toolset_names":"fs, git"}`))
+	s.Equal([]string{"fs_read", "fs_write", "git_status", "toolset_enable", "toolset_disable", "toolset_list"}, toolNames(s.ToolManager.EnabledTools()))
+}
+
+func (s *ToolManagerSuite) TestToolsetEnableInvalidNames() {
+	s.Run("Unknown toolset names are reported", func() {
+		s.Equal("Invalid toolset names: unknown. Valid toolset names: fs, git", s.invokeBuiltIn("toolset_enable", `{"toolset_names":"fs,unknown"}`))
+	})
+	s.Run("Empty toolset names are reported", func() {
+		s.Equal("At least one toolset name is required. Valid toolset names: fs, git", s.invokeBuiltIn("toolset_enable", `{"toolset_names":" , "}`))
+	})
+	s.Run("No toolset is enabled", func() {
+		s.Equal([]string{"toolset_enable", "toolset_disable", "toolset_list"}, toolNames(s.ToolManager.EnabledTools()))
+	})
+}
+
+func (s *ToolManagerSuite) TestToolsetDisable() {
+	s.invokeBuiltIn("toolset_enable", `{"toolset_names":"fs,git"}`)
+	s.Equal("Toolset 'fs' disabled.", s.invokeBuiltIn("toolset_disable", `{"toolset_names":"fs"}`))
+	s.Run("Removes toolset tools from enabled tools", func() {
+		s.Equal([]string{"git_status", "toolset_enable", "toolset_disable", "toolset_list"}, toolNames(s.ToolManager.EnabledTools()))
+		s.Equal(1, s.ToolManager.ToolEnabledCount())
+	})
+	s.Run("Disabled tools can no longer be invoked", func() {
+		result, err := s.ToolManager.InvokeTool(s.T().Context(), "fs_read", "{}")
+		s.NoError(err)
+		s.Equal("Tool 'fs_read' belongs to the 'fs' toolset, which is not enabled. Enable it by calling the 'toolset_enable' tool with toolset_names 'fs', then call 'fs_read' again.", result)
+	})
+	s.Run("Unknown toolset names are reported", func() {
+		s.Equal("Invalid toolset names: unknown. Valid toolset names: fs, git", s.invokeBuiltIn("toolset_disable", `{"toolset_names":"git,unknown"}`))
+		s.Equal([]string{"git_status", "toolset_enable", "toolset_disable", "toolset_list"}, toolNames(s.ToolManager.EnabledTools()))
+	})
+	s.Run("Missing toolset names", func() {
+		s.Equal("Invalid arguments for tool 'toolset_disable':\n"+
+			"- arguments.toolset_names: required property is missing\n"+
+			"Fix the arguments and call the tool again.", s.invokeBuiltIn("toolset_disable", `{}`))
+	})
+}
+
+func (s *ToolManagerSuite) TestToolsetList() {
+	s.invokeBuiltIn("toolset_enable", `{"toolset_names":"git"}`)
+	s.Equal("<toolsets>\n"+
+		`<toolset name="fs" enabled="false" tools="2">File system tools</toolset>`+"\n"+
+		`<toolset name="git" enabled="true" tools="1">Git tools</toolset>`+"\n"+
+		"</toolsets>", s.invokeBuiltIn("toolset_list", ""))
+}
+
+func (s *ToolManagerSuite) TestInvokeToolUnknown() {
+	s.Run("Tool of disabled toolset reports the owning toolset", func() {
+		result, err := s.ToolManager.InvokeTool(s.T().Context(), "git_status", "{}")
+		s.NoError(err)
+		s.Equal("Tool 'git_status' belongs to the 'git' toolset, which is not enabled. Enable it by calling the 'toolset_enable' tool with toolset_names 'git', then call 'git_status' again.", result)
+		s.Equal(0, s.ToolManager.ToolEnabledCount(), "Expected toolset to remain disabled")
+	})
+	s.Run("Tool with different separators reports the owning toolset", func() {
+		result, err := s.ToolManager.InvokeTool(s.T().Context(), "Git-Status", "{}")
+		s.NoError(err)
+		s.Contains(result, "belongs to the 'git' toolset")
+	})
+	s.Run("Similar tool names are suggested", func() {
+		result, err := s.ToolManager.InvokeTool(s.T().Context(), "fs_raed", "{}")
+		s.NoError(err)
+		s.Equal("Tool 'fs_raed' not found. Did you mean one of the following tools?\n"+
+			"- 'fs_read' (toolset 'fs', not enabled, enable it by calling the 'toolset_enable' tool first)", result)
+	})
+	s.Run("Tool name without toolset prefix is suggested", func() {
+		s.invokeBuiltIn("toolset_enable", `{"toolset_names":"git"}`)
+		defer s.ToolManager.EnabledToolsReset()
+		result, err := s.ToolManager.InvokeTool(s.T().Context(), "status", "{}")
+		s.NoError(err)
+		s.Equal("Tool 'status' not found. Did you mean one of the following tools?\n- 'git_status' (toolset 'git')", result)
+	})
+	s.Run("Misspelled enabled tool is invoked", func() {
+		s.invokeBuiltIn("toolset_enable", `{"toolset_names":"git"}`)
+		defer s.ToolManager.EnabledToolsReset()
+		result, err := s.ToolManager.InvokeTool(s.T().Context(), "git-status", "{}")
+		s.NoError(err)
+		s.Equal("clean", result)
+	})
+	s.Run("Unrelated tool is not found", func() {
+		result, err := s.ToolManager.InvokeTool(s.T().Context(), "kubernetes_pods_list", "{}")
+		s.NoError(err)
+		s.Equal("Tool 'kubernetes_pods_list' not found.", result)
+	})
+}
+
+func (s *ToolManagerSuite) TestInvokeToolAutoEnableToolsets() {
+	s.ToolManager.autoEnableToolsets = true
+	result, err := s.ToolManager.InvokeTool(s.T().Context(), "git_status", "{}")
+	s.Run("Tool is invoked", func() {
+		s.NoError(err)
+		s.Equal("clean", result)
+	})
+	s.Run("Owning toolset is enabled", func() {
+		s.Equal([]string{"git_status", "toolset_enable", "toolset_disable", "toolset_list"}, toolNames(s.ToolManager.EnabledTools()))
+	})
+	s.Run("Similar tool names are not auto-enabled", func() {
+		result, err := s.ToolManager.InvokeTool(s.T().Context(), "fs_raed", "{}")
+		s.NoError(err)
+		s.Contains(result, "Did you mean one of the following tools?")
+		s.Equal(1, s.ToolManager.ToolEnabledCount())
+	})
+}
+
+func (s *ToolManagerSuite) TestRestrictTools() {
+	toolsProvider := test.NewToolsProvider("k8s", test.WithToolsAvailable())
+	toolsProvider.Tools = []*api.Tool{
+		{Name: "pods_list", ReadOnly: true},
+		{Name: "pods_label"},
+		{Name: "pods_delete", Destructive: true},
+	}
+	toolsProviders := []api.ToolsProvider{toolsProvider}
+	availableToolNames := func(toolManager *ToolManager) []string {
+		var names []string
+		for _, t := range toolManager.availableTools {
+			names = append(names, t.ToolInfo().Name)
+		}
+		return names
+	}
+	s.Run("read-only keeps read-only tools", func() {
+		toolManager := NewToolManager(toolsProviders, toInvokableTools(s.T().Context(), toolsProviders))
+		toolManager.restrictTools(func(string) api.ToolsParameters { return api.ToolsParameters{ReadOnly: utils.Ptr(true)} })
+		s.Equal([]string{"k8s_pods_list"}, availableToolNames(toolManager))
+		s.Equal("the 'k8s' toolset is read-only", toolManager.restrictedTools["k8s_pods_label"])
+	})
+	s.Run("disable-destructive removes destructive tools", func() {
+		toolManager := NewToolManager(toolsProviders, toInvokableTools(s.T().Context(), toolsProviders))
+		toolManager.restrictTools(func(string) api.ToolsParameters { return api.ToolsParameters{DisableDestructive: utils.Ptr(true)} })
+		s.Equal([]string{"k8s_pods_list", "k8s_pods_label"}, availableToolNames(toolManager))
+		s.Equal("the 'k8s' toolset does not allow destructive tools", toolManager.restrictedTools["k8s_pods_delete"])
+	})
+	s.Run("denied-tools removes matching tools", func() {
+		toolManager := NewToolManager(toolsProviders, toInvokableTools(s.T().Context(), toolsProviders))
+		toolManager.restrictTools(func(string) api.ToolsParameters {
+			return api.ToolsParameters{DeniedTools: []string{"*_delete", "k8s_pods_label"}}
+		})
+		s.Equal([]string{"k8s_pods_list"}, availableToolNames(toolManager))
+		s.Equal("the tool is denied by the '*_delete' pattern", toolManager.restrictedTools["k8s_pods_delete"])
+		s.Equal("the tool is denied by the 'k8s_pods_label' pattern", toolManager.restrictedTools["k8s_pods_label"])
+	})
+	s.Run("allowed-tools keeps only matching tools", func() {
+		toolManager := NewToolManager(toolsProviders, toInvokableTools(s.T().Context(), toolsProviders))
+		toolManager.restrictTools(func(string) api.ToolsParameters { return api.ToolsParameters{AllowedTools: []string{"pods_l*"}} })
+		s.Equal([]string{"k8s_pods_list", "k8s_pods_label"}, availableToolNames(toolManager))
+		s.Equal("the tool does not match any of the allowed tools patterns (pods_l*)", toolManager.restrictedTools["k8s_pods_delete"])
+	})
+	s.Run("denied-tools take precedence over allowed-tools", func() {
+		toolManager := NewToolManager(toolsProviders, toInvokableTools(s.T().Context(), toolsProviders))
+		toolManager.restrictTools(func(string) api.ToolsParameters {
+			return api.ToolsParameters{AllowedTools: []string{"*"}, DeniedTools: []string{"pods_delete"}}
+		})
+		s.Equal([]string{"k8s_pods_list", "k8s_pods_label"}, availableToolNames(toolManager))
+	})
+	s.Run("restricted tools are not enabled with their toolset", func() {
+		toolManager := NewToolManager(toolsProviders, toInvokableTools(s.T().Context(), toolsProviders))
+		toolManager.restrictTools(func(string) api.ToolsParameters { return api.ToolsParameters{ReadOnly: utils.Ptr(true)} })
+		_, _ = toolManager.toolsetEnable(map[string]interface{}{"toolset_names": "k8s"})
+		s.Equal(1, toolManager.ToolEnabledCount())
+	})
+}
+
+func TestToolManager(t *testing.T) {
+	suite.Run(t, new(ToolManagerSuite))
+}
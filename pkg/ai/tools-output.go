@@ -0,0 +1,223 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/spf13/afero"
+)
+
+const toolOutputReadToolName = "tool_output_read"
+
+// toolLimits are the execution limits of a tool
+type toolLimits struct {
+	// timeout is the maximum duration of a tool call (no timeout if 0)
+	timeout time.Duration
+	// maxOutputSize is the maximum size in bytes of the tool result provided to the model (unlimited if 0)
+	maxOutputSize int
+}
+
+// limitExecution sets the timeout and maximum output size of each tool from the parameters of its toolset.
+// The tool_output_read built-in tool is added if the output of any tool is limited.
+func (t *ToolManager) limitExecution(toolsParameters func(toolsetName string) api.ToolsParameters) {
+	t.toolLimits = make(map[string]toolLimits)
+	for _, availableTool := range t.availableTools {
+		if availableTool.ToolsProvider() == nil {
+			continue
+		}
+		toolsetName := availableTool.ToolsProvider().Attributes().Name()
+		parameters := toolsParameters(toolsetName)
+		limits := toolLimits{}
+		if parameters.Timeout != nil {
+			limits.timeout = time.Duration(*parameters.Timeout) * time.Second
+		}
+		for _, pattern := range slices.Sorted(maps.Keys(parameters.ToolTimeouts)) {
+			if matchesToolPattern([]string{pattern}, toolsetName, availableTool.ToolInfo().Name) != "" {
+				limits.timeout = time.Duration(parameters.ToolTimeouts[pattern]) * time.Second
+				break
+			}
+		}
+		if parameters.MaxOutputSize != nil {
+			limits.maxOutputSize = *parameters.MaxOutputSize
+		}
+		t.toolLimits[availableTool.ToolInfo().Name] = limits
+	}
+	if t.toolOutputs == nil {
+		t.toolOutputs = &toolOutputs{chunkSizes: make(map[string]int)}
+	}
+	if slices.ContainsFunc(slices.Collect(maps.Values(t.toolLimits)), func(l toolLimits) bool { return l.maxOutputSize > 0 }) {
+		t.builtInTools = append(t.builtInTools, &invokableTool{
+			toolInfo: &schema.ToolInfo{
+				Name: toolOutputReadToolName,
+				Desc: "Read a tool result that was truncated because it exceeded the maximum output size.",
+				ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+					"output_id": {
+						Type:     schema.String,
+						Desc:     "The ID of the truncated output (provided in the truncated tool result).",
+						Required: true,
+					},
+					"offset": {
+						Type:     schema.Integer,
+						Desc:     "The byte offset to start reading from (provided in the truncated tool result).",
+						Required: true,
+					},
+				}),
+			},
+			readOnly: true,
+			function: t.toolOutputRead,
+		})
+	}
+}
+
+// invokeWithLimits invokes the tool enforcing its timeout and maximum output size.
+// A timed-out call is reported in the result.
+func (t *ToolManager) invokeWithLimits(ctx context.Context, managedTool ToolManagerTool, input string) (string, error) {
+	toolName := managedTool.ToolInfo().Name
+	limits := t.toolLimits[toolName]
+	if limits.timeout <= 0 {
+		output, err := managedTool.InvokableRun(ctx, input)
+		return t.truncate(output, limits.maxOutputSize), err
+	}
+	ctx, cancel := context.WithTimeout(ctx, limits.timeout)
+	defer cancel()
+	type result struct {
+		output string
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		// Native tools don't support cancellation, the call is abandoned if it times out
+		output, err := managedTool.InvokableRun(ctx, input)
+		done <- result{output, err}
+	}()
+	select {
+	case r := <-done:
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			break
+		}
+		return t.truncate(r.output, limits.maxOutputSize), r.err
+	case <-ctx.Done():
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", ctx.Err()
+		}
+	}
+	return fmt.Sprintf("The call to the '%s' tool timed out after %s.", toolName, limits.timeout), nil
+}
+
+// truncate limits the output to maxOutputSize bytes.
+// The complete output is stored so that the model can page through it with the tool_output_read tool.
+func (t *ToolManager) truncate(output string, maxOutputSize int) string {
+	if maxOutputSize <= 0 || len(output) <= maxOutputSize {
+		return output
+	}
+	outputId, err := t.toolOutputs.save(output, maxOutputSize)
+	if err != nil {
+		return fmt.Sprintf("%s\n[Output truncated: showing the first %d of %d bytes.]",
+			truncateString(output, maxOutputSize), maxOutputSize, len(output))
+	}
+	return t.toolOutputs.chunk(outputId, output, 0)
+}
+
+func (t *ToolManager) toolOutputRead(args map[string]interface{}) (string, error) {
+	outputId, _ := args["output_id"].(string)
+	offset, _ := args["offset"].(float64)
+	output, err := t.toolOutputs.read(outputId)
+	if err != nil {
+		return fmt.Sprintf("Output '%s' not found.", outputId), nil
+	}
+	if int(offset) < 0 || int(offset) >= len(output) {
+		return fmt.Sprintf("Offset %d is out of range, the output has %d bytes.", int(offset), len(output)), nil
+	}
+	return t.toolOutputs.chunk(outputId, output, int(offset)), nil
+}
+
+// toolOutputs stores the tool results that exceed the maximum output size in a temporary directory
+type toolOutputs struct {
+	mutex sync.Mutex
+	dir   string
+	// chunkSizes are the maximum output sizes of the tools that produced the stored outputs (output ID -> size)
+	chunkSizes map[string]int
+}
+
+func (o *toolOutputs) save(output string, chunkSize int) (string, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.dir == "" {
+		dir, err := afero.TempDir(config.FileSystem, "", "ai-cli-tool-output-")
+		if err != nil {
+			return "", err
+		}
+		o.dir = dir
+	}
+	f, err := afero.TempFile(config.FileSystem, o.dir, "output-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	if _, err = f.WriteString(output); err != nil {
+		return "", err
+	}
+	outputId := strings.TrimSuffix(filepath.Base(f.Name()), ".txt")
+	o.chunkSizes[outputId] = chunkSize
+	return outputId, nil
+}
+
+func (o *toolOutputs) read(outputId string) (string, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if _, exists := o.chunkSizes[outputId]; !exists {
+		return "", fmt.Errorf("output %s not found", outputId)
+	}
+	data, err := afero.ReadFile(config.FileSystem, filepath.Join(o.dir, outputId+".txt"))
+	return string(data), err
+}
+
+// chunk returns the part of the output starting at offset, with a marker pointing to the next part (if any)
+func (o *toolOutputs) chunk(outputId, output string, offset int) string {
+	o.mutex.Lock()
+	chunkSize := o.chunkSizes[outputId]
+	o.mutex.Unlock()
+	chunk := truncateString(output[offset:], chunkSize)
+	next := offset + len(chunk)
+	if next >= len(output) {
+		return chunk
+	}
+	return fmt.Sprintf("%s\n[Output truncated: showing bytes %d-%d of %d. "+
+		"Call the '%s' tool with output_id '%s' and offset %d to read more.]",
+		chunk, offset, next, len(output), toolOutputReadToolName, outputId, next)
+}
+
+func (o *toolOutputs) close() {
+	if o == nil {
+		return
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.dir != "" {
+		_ = config.FileSystem.RemoveAll(o.dir)
+		o.dir = ""
+	}
+	o.chunkSizes = make(map[string]int)
+}
+
+// truncateString returns the first size bytes of s without splitting a multibyte character
+func truncateString(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+	for size > 0 && !utf8.RuneStart(s[size]) {
+		size--
+	}
+	return s[:size]
+}
@@ -0,0 +1,104 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/suite"
+)
+
+type ToolsOutputSuite struct {
+	suite.Suite
+	originalFileSystem afero.Fs
+	ToolManager        *ToolManager
+}
+
+func (s *ToolsOutputSuite) SetupTest() {
+	s.originalFileSystem = config.FileSystem
+	config.FileSystem = afero.NewMemMapFs()
+	toolsProvider := test.NewToolsProvider("test", test.WithToolsAvailable())
+	toolsProvider.Tools = []*api.Tool{
+		{Name: "hang", Function: func(map[string]interface{}) (string, error) {
+			time.Sleep(5 * time.Second)
+			return "finished", nil
+		}},
+		{Name: "list", Function: func(map[string]interface{}) (string, error) {
+			return strings.Repeat("0123456789", 3), nil
+		}},
+	}
+	toolsProviders := []api.ToolsProvider{toolsProvider}
+	s.ToolManager = NewToolManager(toolsProviders, toInvokableTools(s.T().Context(), toolsProviders))
+	s.ToolManager.limitExecution(func(string) api.ToolsParameters {
+		return api.ToolsParameters{Timeout: utils.Ptr(60), ToolTimeouts: map[string]int{"hang": 1}, MaxOutputSize: utils.Ptr(12)}
+	})
+	_, _ = s.ToolManager.toolsetEnable(map[string]interface{}{"toolset_names": "test"})
+}
+
+func (s *ToolsOutputSuite) TearDownTest() {
+	s.ToolManager.Close()
+	config.FileSystem = s.originalFileSystem
+}
+
+func (s *ToolsOutputSuite) invoke(name, input string) string {
+	result, err := s.ToolManager.InvokeTool(s.T().Context(), name, input)
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *ToolsOutputSuite) TestLimitExecution() {
+	s.Run("Tool timeouts take precedence over the toolset timeout", func() {
+		s.Equal(time.Second, s.ToolManager.toolLimits["test_hang"].timeout)
+		s.Equal(time.Minute, s.ToolManager.toolLimits["test_list"].timeout)
+	})
+	s.Run("Adds tool_output_read built-in tool", func() {
+		s.Contains(toolNames(s.ToolManager.BuiltInTools()), "tool_output_read")
+	})
+}
+
+func (s *ToolsOutputSuite) TestTimeout() {
+	limits := s.ToolManager.toolLimits["test_hang"]
+	limits.timeout = 50 * time.Millisecond
+	s.ToolManager.toolLimits["test_hang"] = limits
+	s.Equal("The call to the 'test_hang' tool timed out after 50ms.", s.invoke("test_hang", "{}"))
+}
+
+func (s *ToolsOutputSuite) TestTruncatedOutput() {
+	result := s.invoke("test_list", "{}")
+	s.Run("Truncates the output with a marker", func() {
+		s.Regexp(`^012345678901\n\[Output truncated: showing bytes 0-12 of 30\. `+
+			`Call the 'tool_output_read' tool with output_id '(output-\d+)' and offset 12 to read more\.]$`, result)
+	})
+	outputId := strings.Split(strings.Split(result, "output_id '")[1], "'")[0]
+	s.Run("tool_output_read pages through the output", func() {
+		s.Equal("234567890123\n[Output truncated: showing bytes 12-24 of 30. "+
+			"Call the 'tool_output_read' tool with output_id '"+outputId+"' and offset 24 to read more.]",
+			s.invoke("tool_output_read", `{"output_id":"`+outputId+`","offset":12}`))
+		s.Equal("456789", s.invoke("tool_output_read", `{"output_id":"`+outputId+`","offset":24}`))
+	})
+	s.Run("tool_output_read with invalid offset", func() {
+		s.Equal("Offset 30 is out of range, the output has 30 bytes.",
+			s.invoke("tool_output_read", `{"output_id":"`+outputId+`","offset":30}`))
+	})
+	s.Run("tool_output_read with unknown output", func() {
+		s.Equal("Output 'output-0' not found.", s.invoke("tool_output_read", `{"output_id":"output-0","offset":0}`))
+	})
+	s.Run("Close removes the stored outputs", func() {
+		s.ToolManager.Close()
+		s.Equal("Output '"+outputId+"' not found.", s.invoke("tool_output_read", `{"output_id":"`+outputId+`","offset":0}`))
+	})
+}
+
+func (s *ToolsOutputSuite) TestTruncateStringKeepsMultibyteCharacters() {
+	s.Equal("a", truncateString("añb", 2))
+	s.Equal("añ", truncateString("añb", 3))
+}
+
+func TestToolsOutput(t *testing.T) {
+	suite.Run(t, new(ToolsOutputSuite))
+}
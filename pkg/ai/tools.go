@@ -3,15 +3,25 @@ package ai
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/schema"
+	"github.com/eino-contrib/jsonschema"
 	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
 )
 
 type invokableTool struct {
 	toolsProvider api.ToolsProvider
 	toolInfo      *schema.ToolInfo
+	readOnly      bool
+	destructive   bool
 	function      func(args map[string]interface{}) (string, error)
 }
 
@@ -25,11 +35,22 @@ func (i invokableTool) ToolInfo() *schema.ToolInfo {
 	return i.toolInfo
 }
 
+func (i invokableTool) ReadOnly() bool {
+	return i.readOnly
+}
+
+func (i invokableTool) Destructive() bool {
+	return i.destructive
+}
+
 func (i invokableTool) Info(_ context.Context) (*schema.ToolInfo, error) {
 	return i.toolInfo, nil
 }
 
 func (i invokableTool) InvokableRun(_ context.Context, argumentsInJSON string, _ ...tool.Option) (string, error) {
+	if violations := validateArguments(i.toolInfo, argumentsInJSON); violations != "" {
+		return violations, nil
+	}
 	var args map[string]interface{}
 	if argumentsInJSON != "" {
 		if err := json.Unmarshal([]byte(argumentsInJSON), &args); err != nil {
@@ -37,37 +58,180 @@ func (i invokableTool) InvokableRun(_ context.Context, argumentsInJSON string, _
 		}
 
 	}
-	return i.function(args)
+	result, err := i.function(args)
+	// Errors the model can correct (e.g. access denied by the toolset parameters) are provided as the tool result
+	var resultError *api.ToolResultError
+	if errors.As(err, &resultError) {
+		return resultError.Message, nil
+	}
+	return result, err
 }
 
 func toType(t api.ToolParameterType) schema.DataType {
 	switch t {
 	case api.String:
 		return schema.String
+	case api.Integer:
+		return schema.Integer
+	case api.Number:
+		return schema.Number
+	case api.Boolean:
+		return schema.Boolean
+	case api.Array:
+		return schema.Array
 	}
 	return schema.Object
 }
 
+// toJSONSchema converts the tool parameters to the JSON schema of the tool arguments (object)
+func toJSONSchema(parameters map[string]api.ToolParameter) *jsonschema.Schema {
+	jsonSchema := &jsonschema.Schema{
+		Type:       string(schema.Object),
+		Properties: jsonschema.NewProperties(),
+		Required:   make([]string, 0),
+	}
+	for _, key := range slices.Sorted(maps.Keys(parameters)) {
+		jsonSchema.Properties.Set(key, toParameterJSONSchema(parameters[key]))
+		if parameters[key].Required {
+			jsonSchema.Required = append(jsonSchema.Required, key)
+		}
+	}
+	return jsonSchema
+}
+
+func toParameterJSONSchema(parameter api.ToolParameter) *jsonschema.Schema {
+	jsonSchema := &jsonschema.Schema{
+		Type:        string(toType(parameter.Type)),
+		Description: parameter.Description,
+		Default:     parameter.Default,
+	}
+	for _, enum := range parameter.Enum {
+		jsonSchema.Enum = append(jsonSchema.Enum, enum)
+	}
+	if parameter.Minimum != nil {
+		jsonSchema.Minimum = json.Number(strconv.FormatFloat(*parameter.Minimum, 'f', -1, 64))
+	}
+	if parameter.Maximum != nil {
+		jsonSchema.Maximum = json.Number(strconv.FormatFloat(*parameter.Maximum, 'f', -1, 64))
+	}
+	if parameter.Items != nil {
+		jsonSchema.Items = toParameterJSONSchema(*parameter.Items)
+	}
+	if len(parameter.Properties) > 0 {
+		properties := toJSONSchema(parameter.Properties)
+		jsonSchema.Properties = properties.Properties
+		jsonSchema.Required = properties.Required
+	}
+	return jsonSchema
+}
+
 func toInvokableTools(ctx context.Context, toolsProviders []api.ToolsProvider) (tools []ToolManagerTool) {
 	tools = make([]ToolManagerTool, 0)
 	for _, provider := range toolsProviders {
 		for _, t := range provider.GetTools(ctx) {
-			params := make(map[string]*schema.ParameterInfo, len(t.Parameters))
-			for parameterKey, parameter := range t.Parameters {
-				params[parameterKey] = &schema.ParameterInfo{
-					Type:     toType(parameter.Type),
-					Desc:     parameter.Description,
-					Required: parameter.Required,
-				}
-			}
-
 			toolInfo := &schema.ToolInfo{
 				Name:        provider.Attributes().Name() + "_" + t.Name,
 				Desc:        t.Description,
-				ParamsOneOf: schema.NewParamsOneOfByParams(params),
+				ParamsOneOf: schema.NewParamsOneOfByJSONSchema(toJSONSchema(t.Parameters)),
 			}
-			tools = append(tools, &invokableTool{function: t.Function, toolInfo: toolInfo, toolsProvider: provider})
+			tools = append(tools, &invokableTool{function: t.Function, toolInfo: toolInfo, toolsProvider: provider, readOnly: t.ReadOnly, destructive: t.Destructive})
 		}
 	}
 	return tools
 }
+
+// ToolsInspection lists the issues of the tools of the available toolsets
+type ToolsInspection struct {
+	// FilteredTools are the tools filtered out by the toolset parameters (config and policies)
+	FilteredTools []api.FilteredTool
+	// DuplicateTools are the tool names exposed by more than one toolset
+	DuplicateTools []api.DuplicateTool
+	// EmptyToolsets are the toolsets with all of their tools filtered out by the toolset parameters
+	EmptyToolsets []api.EmptyToolset
+}
+
+// InspectTools returns the tools of the provided toolsets that are filtered out by the toolset parameters (config and policies),
+// the toolsets left without tools, and the tool names exposed by more than one toolset.
+// The MCP servers are started to list their tools and stopped right away.
+func InspectTools(ctx context.Context, toolsProviders []api.ToolsProvider) (inspection ToolsInspection) {
+	inspection.FilteredTools = make([]api.FilteredTool, 0)
+	inspection.EmptyToolsets = make([]api.EmptyToolset, 0)
+	tools := toInvokableTools(ctx, toolsProviders)
+	mcpClients := StartMcpClients(ctx, toolsProviders)
+	defer StopMcpClients(mcpClients)
+	tools = append(tools, ToMcpTools(ctx, mcpClients)...)
+	inspection.DuplicateTools = findDuplicateTools(tools)
+	cfg := config.GetConfig(ctx)
+	if cfg == nil {
+		return inspection
+	}
+	toolManager := NewToolManager(toolsProviders, tools)
+	toolManager.restrictTools(cfg.ToolsParameters)
+	for _, t := range tools {
+		if reason, restricted := toolManager.restrictedTools[t.ToolInfo().Name]; restricted {
+			inspection.FilteredTools = append(inspection.FilteredTools, api.FilteredTool{
+				Toolset: t.ToolsProvider().Attributes().Name(),
+				Name:    t.ToolInfo().Name,
+				Reason:  reason,
+			})
+		}
+	}
+	for _, toolsProvider := range toolsProviders {
+		if emptyToolset := findEmptyToolset(toolsProvider, tools, toolManager.restrictedTools, cfg.ToolsParameters); emptyToolset != nil {
+			inspection.EmptyToolsets = append(inspection.EmptyToolsets, *emptyToolset)
+		}
+	}
+	return inspection
+}
+
+// findEmptyToolset returns the toolset if it provides tools but all of them are restricted, with the reasons.
+// The read-only and non-destructive restrictions rely on the tool hints, MCP servers that don't annotate their tools
+// lose all of them (MCP tools are destructive and not read-only unless annotated otherwise).
+func findEmptyToolset(toolsProvider api.ToolsProvider, tools []ToolManagerTool, restrictedTools map[string]string,
+	toolsParameters func(toolsetName string) api.ToolsParameters) *api.EmptyToolset {
+	toolsetName := toolsProvider.Attributes().Name()
+	var reasons []string
+	for _, t := range tools {
+		if t.ToolsProvider() != toolsProvider {
+			continue
+		}
+		reason, restricted := restrictedTools[t.ToolInfo().Name]
+		if !restricted {
+			return nil
+		}
+		if !slices.Contains(reasons, reason) {
+			reasons = append(reasons, reason)
+		}
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+	reason := fmt.Sprintf("all of its tools are filtered out (%s)", strings.Join(reasons, ", "))
+	parameters := toolsParameters(toolsetName)
+	if (parameters.ReadOnly != nil && *parameters.ReadOnly) || (parameters.DisableDestructive != nil && *parameters.DisableDestructive) {
+		reason += ", tools are considered destructive and not read-only unless annotated otherwise"
+	}
+	return &api.EmptyToolset{Toolset: toolsetName, Reason: reason}
+}
+
+// findDuplicateTools returns the tool names (without the toolset prefix) exposed by more than one toolset, sorted by name.
+func findDuplicateTools(tools []ToolManagerTool) []api.DuplicateTool {
+	toolsets := make(map[string][]string)
+	for _, t := range tools {
+		if t.ToolsProvider() == nil {
+			continue
+		}
+		toolsetName := t.ToolsProvider().Attributes().Name()
+		name := strings.TrimPrefix(t.ToolInfo().Name, toolsetName+"_")
+		if !slices.Contains(toolsets[name], toolsetName) {
+			toolsets[name] = append(toolsets[name], toolsetName)
+		}
+	}
+	duplicateTools := make([]api.DuplicateTool, 0)
+	for _, name := range slices.Sorted(maps.Keys(toolsets)) {
+		if len(toolsets[name]) > 1 {
+			duplicateTools = append(duplicateTools, api.DuplicateTool{Name: name, Toolsets: toolsets[name]})
+		}
+	}
+	return duplicateTools
+}
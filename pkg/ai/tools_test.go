@@ -0,0 +1,139 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/policies"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type ToolsSuite struct {
+	suite.Suite
+}
+
+func (s *ToolsSuite) TestToInvokableToolsParameters() {
+	toolsProvider := test.NewToolsProvider("test", test.WithToolsAvailable())
+	toolsProvider.Tools = []*api.Tool{{
+		Name:        "search",
+		Description: "Search things",
+		Parameters: map[string]api.ToolParameter{
+			"query":     {Type: api.String, Description: "The query.", Required: true},
+			"recursive": {Type: api.Boolean, Description: "Search recursively.", Default: false},
+			"max_depth": {Type: api.Integer, Description: "The maximum depth.", Minimum: utils.Ptr(1.0), Maximum: utils.Ptr(10.0)},
+			"ratio":     {Type: api.Number, Description: "The ratio."},
+			"mode":      {Type: api.String, Description: "The mode.", Enum: []string{"fast", "accurate"}},
+			"tags":      {Type: api.Array, Description: "The tags.", Items: &api.ToolParameter{Type: api.String}},
+			"filter": {Type: api.Object, Description: "The filter.", Properties: map[string]api.ToolParameter{
+				"name": {Type: api.String, Description: "The name.", Required: true},
+			}},
+		},
+	}}
+	tools := toInvokableTools(s.T().Context(), []api.ToolsProvider{toolsProvider})
+	s.Require().Len(tools, 1)
+	jsonSchema, err := tools[0].ToolInfo().ParamsOneOf.ToJSONSchema()
+	s.Require().NoError(err)
+	properties := func(name string) map[string]interface{} {
+		property, _ := jsonSchema.Properties.Get(name)
+		marshalled, _ := json.Marshal(property)
+		var ret map[string]interface{}
+		_ = json.Unmarshal(marshalled, &ret)
+		return ret
+	}
+	s.Run("required parameters", func() {
+		s.Equal([]string{"query"}, jsonSchema.Required)
+	})
+	s.Run("boolean with default", func() {
+		s.Equal(map[string]interface{}{"type": "boolean", "description": "Search recursively.", "default": false}, properties("recursive"))
+	})
+	s.Run("integer with range", func() {
+		s.Equal(map[string]interface{}{"type": "integer", "description": "The maximum depth.", "minimum": 1.0, "maximum": 10.0}, properties("max_depth"))
+	})
+	s.Run("number", func() {
+		s.Equal("number", properties("ratio")["type"])
+	})
+	s.Run("enum", func() {
+		s.Equal([]interface{}{"fast", "accurate"}, properties("mode")["enum"])
+	})
+	s.Run("array with items", func() {
+		s.Equal("array", properties("tags")["type"])
+		s.Equal(map[string]interface{}{"type": "string"}, properties("tags")["items"])
+	})
+	s.Run("object with properties", func() {
+		s.Equal("object", properties("filter")["type"])
+		s.Equal(map[string]interface{}{"name": map[string]interface{}{"type": "string", "description": "The name."}}, properties("filter")["properties"])
+		s.Equal([]interface{}{"name"}, properties("filter")["required"])
+	})
+	s.Run("arguments out of range are rejected", func() {
+		result, err := tools[0].InvokableRun(s.T().Context(), `{"query":"q","max_depth":11}`)
+		s.NoError(err)
+		s.Equal("Invalid arguments for tool 'test_search':\n"+
+			"- arguments.max_depth: 11 is greater than the maximum 10\n"+
+			"Fix the arguments and call the tool again.", result)
+	})
+}
+
+func (s *ToolsSuite) TestInspectToolsEmptyToolsets() {
+	k8s := test.NewToolsProvider("k8s", test.WithToolsAvailable())
+	k8s.Tools = []*api.Tool{{Name: "pods_label"}, {Name: "pods_delete", Destructive: true}}
+	git := test.NewToolsProvider("git", test.WithToolsAvailable())
+	git.Tools = []*api.Tool{{Name: "status", ReadOnly: true}, {Name: "commit"}}
+	toolsProviders := []api.ToolsProvider{k8s, git}
+	s.Run("Reports toolsets with all of their tools filtered out by the read-only restriction", func() {
+		cfg := config.New()
+		cfg.Enforce(test.Must(policies.ReadToml("[tools]\nread-only = true")))
+		inspection := InspectTools(config.WithConfig(s.T().Context(), cfg), toolsProviders)
+		s.Equal([]api.EmptyToolset{{
+			Toolset: "k8s",
+			Reason: "all of its tools are filtered out (the 'k8s' toolset is read-only), " +
+				"tools are considered destructive and not read-only unless annotated otherwise",
+		}}, inspection.EmptyToolsets)
+	})
+	s.Run("Reports toolsets with all of their tools filtered out by patterns", func() {
+		cfg := config.New()
+		cfg.Enforce(test.Must(policies.ReadToml("[tools.provider.git]\ndenied-tools = [\"status\", \"commit\"]")))
+		inspection := InspectTools(config.WithConfig(s.T().Context(), cfg), toolsProviders)
+		s.Equal([]api.EmptyToolset{{
+			Toolset: "git",
+			Reason:  "all of its tools are filtered out (the tool is denied by the 'status' pattern, the tool is denied by the 'commit' pattern)",
+		}}, inspection.EmptyToolsets)
+	})
+	s.Run("Doesn't report toolsets with available tools", func() {
+		inspection := InspectTools(config.WithConfig(s.T().Context(), config.New()), toolsProviders)
+		s.Empty(inspection.EmptyToolsets)
+	})
+}
+
+func (s *ToolsSuite) TestInvokableRunToolResultError() {
+	invoke := func(err error) (string, error) {
+		return invokableTool{
+			toolInfo: &schema.ToolInfo{Name: "test_tool"},
+			function: func(map[string]interface{}) (string, error) { return "", err },
+		}.InvokableRun(s.T().Context(), "{}")
+	}
+	s.Run("ToolResultError is provided as the tool result", func() {
+		result, err := invoke(api.NewDeniedError("Access", "the path is outside the allowed directories"))
+		s.NoError(err)
+		s.Equal("Access denied: the path is outside the allowed directories.", result)
+	})
+	s.Run("Wrapped ToolResultError is provided as the tool result", func() {
+		result, err := invoke(fmt.Errorf("get: %w", api.NewDeniedError("Access", "the domain is denied")))
+		s.NoError(err)
+		s.Equal("Access denied: the domain is denied.", result)
+	})
+	s.Run("Other errors are returned", func() {
+		_, err := invoke(errors.New("unexpected failure"))
+		s.EqualError(err, "unexpected failure")
+	})
+}
+
+func TestTools(t *testing.T) {
+	suite.Run(t, new(ToolsSuite))
+}
@@ -0,0 +1,158 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/eino-contrib/jsonschema"
+)
+
+// validateArguments validates the tool call arguments against the tool JSON schema.
+// Returns a model-readable description of every violation so that the model can fix the call in the next step,
+// or an empty string if the arguments are valid (or the tool has no schema).
+func validateArguments(toolInfo *schema.ToolInfo, argumentsInJSON string) string {
+	if toolInfo == nil || toolInfo.ParamsOneOf == nil {
+		return ""
+	}
+	jsonSchema, err := toolInfo.ParamsOneOf.ToJSONSchema()
+	if err != nil || jsonSchema == nil {
+		return ""
+	}
+	var arguments any = map[string]any{}
+	if strings.TrimSpace(argumentsInJSON) != "" {
+		if err = json.Unmarshal([]byte(argumentsInJSON), &arguments); err != nil {
+			return fmt.Sprintf("Invalid arguments for tool '%s': the arguments are not valid JSON (%s).\n"+
+				"Fix the arguments and call the tool again.", toolInfo.Name, err.Error())
+		}
+	}
+	var violations []string
+	validateValue("arguments", jsonSchema, arguments, &violations)
+	if len(violations) == 0 {
+		return ""
+	}
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("Invalid arguments for tool '%s':\n", toolInfo.Name))
+	for _, violation := range violations {
+		sb.WriteString("- " + violation + "\n")
+	}
+	sb.WriteString("Fix the arguments and call the tool again.")
+	return sb.String()
+}
+
+func validateValue(path string, jsonSchema *jsonschema.Schema, value any, violations *[]string) {
+	if jsonSchema == nil {
+		return
+	}
+	types := slices.Clone(jsonSchema.TypeEnhanced)
+	if jsonSchema.Type != "" {
+		types = append(types, jsonSchema.Type)
+	}
+	if len(types) > 0 && !slices.ContainsFunc(types, func(t string) bool { return isType(t, value) }) {
+		*violations = append(*violations, fmt.Sprintf("%s: expected %s, got %s", path, strings.Join(types, " or "), typeOf(value)))
+		return
+	}
+	if len(jsonSchema.Enum) > 0 && !slices.ContainsFunc(jsonSchema.Enum, func(e any) bool { return reflect.DeepEqual(e, value) }) {
+		*violations = append(*violations, fmt.Sprintf("%s: %v is not one of the allowed values %v", path, value, jsonSchema.Enum))
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		for _, required := range jsonSchema.Required {
+			if _, ok := v[required]; !ok {
+				*violations = append(*violations, fmt.Sprintf("%s.%s: required property is missing", path, required))
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			var propertySchema *jsonschema.Schema
+			if jsonSchema.Properties != nil {
+				propertySchema, _ = jsonSchema.Properties.Get(key)
+			}
+			if propertySchema == nil && isFalseSchema(jsonSchema.AdditionalProperties) {
+				*violations = append(*violations, fmt.Sprintf("%s.%s: unknown property", path, key))
+				continue
+			}
+			validateValue(path+"."+key, propertySchema, v[key], violations)
+		}
+	case []any:
+		if jsonSchema.MinItems != nil && uint64(len(v)) < *jsonSchema.MinItems {
+			*violations = append(*violations, fmt.Sprintf("%s: expected at least %d items, got %d", path, *jsonSchema.MinItems, len(v)))
+		}
+		if jsonSchema.MaxItems != nil && uint64(len(v)) > *jsonSchema.MaxItems {
+			*violations = append(*violations, fmt.Sprintf("%s: expected at most %d items, got %d", path, *jsonSchema.MaxItems, len(v)))
+		}
+		for i, item := range v {
+			validateValue(fmt.Sprintf("%s[%d]", path, i), jsonSchema.Items, item, violations)
+		}
+	case string:
+		length := uint64(utf8.RuneCountInString(v))
+		if jsonSchema.MinLength != nil && length < *jsonSchema.MinLength {
+			*violations = append(*violations, fmt.Sprintf("%s: expected at least %d characters, got %d", path, *jsonSchema.MinLength, length))
+		}
+		if jsonSchema.MaxLength != nil && length > *jsonSchema.MaxLength {
+			*violations = append(*violations, fmt.Sprintf("%s: expected at most %d characters, got %d", path, *jsonSchema.MaxLength, length))
+		}
+	case float64:
+		if minimum, err := jsonSchema.Minimum.Float64(); err == nil && v < minimum {
+			*violations = append(*violations, fmt.Sprintf("%s: %v is less than the minimum %v", path, v, minimum))
+		}
+		if maximum, err := jsonSchema.Maximum.Float64(); err == nil && v > maximum {
+			*violations = append(*violations, fmt.Sprintf("%s: %v is greater than the maximum %v", path, v, maximum))
+		}
+	}
+}
+
+func isType(t string, value any) bool {
+	switch t {
+	case string(schema.Object):
+		_, ok := value.(map[string]any)
+		return ok
+	case string(schema.Array):
+		_, ok := value.([]any)
+		return ok
+	case string(schema.String):
+		_, ok := value.(string)
+		return ok
+	case string(schema.Boolean):
+		_, ok := value.(bool)
+		return ok
+	case string(schema.Number):
+		_, ok := value.(float64)
+		return ok
+	case string(schema.Integer):
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case string(schema.Null):
+		return value == nil
+	}
+	return true // Unknown types are not validated
+}
+
+func typeOf(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return string(schema.Object)
+	case []any:
+		return string(schema.Array)
+	case string:
+		return string(schema.String)
+	case bool:
+		return string(schema.Boolean)
+	case float64:
+		return string(schema.Number)
+	}
+	return string(schema.Null)
+}
+
+func isFalseSchema(jsonSchema *jsonschema.Schema) bool {
+	if jsonSchema == nil {
+		return false
+	}
+	marshalled, err := json.Marshal(jsonSchema)
+	return err == nil && string(marshalled) == "false"
+}
@@ -0,0 +1,101 @@
+package ai
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/eino-contrib/jsonschema"
+	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidationSuite struct {
+	suite.Suite
+	toolInfo *schema.ToolInfo
+}
+
+func (s *ValidationSuite) SetupTest() {
+	s.toolInfo = &schema.ToolInfo{
+		Name: "search",
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"query":     {Type: schema.String, Required: true},
+			"max_depth": {Type: schema.Integer},
+			"mode":      {Type: schema.String, Enum: []string{"fast", "accurate"}},
+			"tags":      {Type: schema.Array, ElemInfo: &schema.ParameterInfo{Type: schema.String}},
+			"filter": {Type: schema.Object, SubParams: map[string]*schema.ParameterInfo{
+				"name": {Type: schema.String, Required: true},
+			}},
+		}),
+	}
+}
+
+func (s *ValidationSuite) TestValidArguments() {
+	s.Empty(validateArguments(s.toolInfo, `{"query":"pods","max_depth":2,"mode":"fast","tags":["a"],"filter":{"name":"x"}}`))
+}
+
+func (s *ValidationSuite) TestEmptyArgumentsWithoutRequiredParameters() {
+	s.Empty(validateArguments(&schema.ToolInfo{Name: "list", ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{})}, ""))
+}
+
+func (s *ValidationSuite) TestInvalidJson() {
+	s.Equal("Invalid arguments for tool 'search': the arguments are not valid JSON (unexpected end of JSON input).\n"+
+		"Fix the arguments and call the tool again.", validateArguments(s.toolInfo, `{"query":`))
+}
+
+func (s *ValidationSuite) TestViolationsAreListed() {
+	s.Equal("Invalid arguments for tool 'search':\n"+
+		"- arguments.query: required property is missing\n"+
+		"- arguments.filter.name: required property is missing\n"+
+		"- arguments.max_depth: expected integer, got string\n"+
+		"- arguments.mode: slow is not one of the allowed values [fast accurate]\n"+
+		"- arguments.tags[1]: expected string, got number\n"+
+		"Fix the arguments and call the tool again.",
+		validateArguments(s.toolInfo, `{"max_depth":"2","mode":"slow","tags":["a",1],"filter":{}}`))
+}
+
+func (s *ValidationSuite) TestJsonSchemaConstraints() {
+	jsonSchema := &jsonschema.Schema{}
+	s.Require().NoError(json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"replicas": {"type": "integer", "minimum": 1, "maximum": 5},
+			"name": {"type": "string", "minLength": 3},
+			"labels": {"type": "array", "maxItems": 1}
+		},
+		"additionalProperties": false
+	}`), jsonSchema))
+	s.Equal("Invalid arguments for tool 'scale':\n"+
+		"- arguments.labels: expected at most 1 items, got 2\n"+
+		"- arguments.name: expected at least 3 characters, got 2\n"+
+		"- arguments.namespace: unknown property\n"+
+		"- arguments.replicas: 10 is greater than the maximum 5\n"+
+		"Fix the arguments and call the tool again.",
+		validateArguments(&schema.ToolInfo{Name: "scale", ParamsOneOf: schema.NewParamsOneOfByJSONSchema(jsonSchema)},
+			`{"replicas":10,"name":"ab","labels":["a","b"],"namespace":"default"}`))
+}
+
+func (s *ValidationSuite) TestNativeToolIsNotCalledWithInvalidArguments() {
+	called := false
+	toolsProvider := test.NewToolsProvider("test", test.WithToolsAvailable())
+	toolsProvider.Tools = []*api.Tool{{
+		Name:       "echo",
+		Parameters: map[string]api.ToolParameter{"text": {Type: api.String, Required: true}},
+		Function: func(args map[string]interface{}) (string, error) {
+			called = true
+			return args["text"].(string), nil
+		},
+	}}
+	tools := toInvokableTools(s.T().Context(), []api.ToolsProvider{toolsProvider})
+	result, err := tools[0].InvokableRun(s.T().Context(), `{}`)
+	s.NoError(err)
+	s.False(called)
+	s.Equal("Invalid arguments for tool 'test_echo':\n"+
+		"- arguments.text: required property is missing\n"+
+		"Fix the arguments and call the tool again.", result)
+}
+
+func TestValidation(t *testing.T) {
+	suite.Run(t, new(ValidationSuite))
+}
@@ -7,6 +7,8 @@ type Ai interface {
 	Reset()
 	Session() Session
 	Input() chan Message
+	// RespondToolCallApproval provides the user decision for the tool call pending approval (if any)
+	RespondToolCallApproval(response ToolCallApprovalResponse)
 }
 
 type Session interface {
@@ -14,4 +16,36 @@ type Session interface {
 	Messages() []Message
 	SystemPrompt() Message
 	IsRunning() bool
+	// PendingToolCallApproval returns the tool call that is waiting for the user approval, or nil if there's none
+	PendingToolCallApproval() *ToolCallApproval
+}
+
+// ToolCallApproval is a call to a destructive tool that requires the user approval before being executed
+type ToolCallApproval struct {
+	ToolName string
+	// Arguments of the tool call (pretty-printed JSON)
+	Arguments string
+}
+
+// ToolCallApprovalResponse is the user decision for a ToolCallApproval
+type ToolCallApprovalResponse struct {
+	Approved bool
+	// Reason provided by the user when denying the tool call
+	Reason string
+	// AlwaysAllow approves the tool call and any subsequent call to the same tool for the rest of the session
+	AlwaysAllow bool
+}
+
+// AgentsParameters parameters for the agent and its delegated sub-agents
+type AgentsParameters struct {
+	// MaxDepth is the maximum nesting level for delegated sub-agents (0 disables sub-agents)
+	MaxDepth *int `json:"-" toml:"max-depth"`
+	// MaxSteps is the maximum number of steps a delegated sub-agent can perform
+	MaxSteps *int `json:"-" toml:"max-steps"`
+	// AutoEnableToolsets enables the owning toolset and runs the call when the model calls a tool of a disabled toolset
+	AutoEnableToolsets *bool `json:"-" toml:"auto-enable-toolsets"`
+	// RequireApproval pauses the agent and asks the user for approval before calling a destructive tool
+	RequireApproval *bool `json:"-" toml:"require-approval"`
+	// ParallelToolCalls executes the tool calls requested by the model in the same step concurrently
+	ParallelToolCalls *bool `json:"-" toml:"parallel-tool-calls"`
 }
@@ -13,6 +13,8 @@ type InferenceProvider interface {
 	GetModel(ctx context.Context) (string, error)
 	// Models returns the list of supported models by the inference provider
 	Models() []string
+	// SupportsVision returns true if the selected model accepts image and document (PDF) attachments
+	SupportsVision() bool
 	SystemPrompt() string
 	InstallHelp() error
 	Clear(ctx context.Context) (bool, error)
@@ -29,6 +31,8 @@ type InferenceAttributes interface {
 type InferenceParameters struct {
 	Enabled *bool   `json:"-" toml:"enabled"`
 	Model   *string `json:"-" toml:"enabled"` // A model to use, if not set, the best model will be used
+	// Vision overrides the detection of the image and document (PDF) attachments support of the selected model
+	Vision *bool `json:"-" toml:"vision"`
 }
 
 type BasicInferenceProvider struct {
@@ -56,6 +60,12 @@ func (p *BasicInferenceProvider) Models() []string {
 	return p.ProviderModels
 }
 
+// SupportsVision returns the configured vision parameter, providers that can detect the capabilities of the selected
+// model should override it (but still honor the configuration)
+func (p *BasicInferenceProvider) SupportsVision() bool {
+	return p.Vision != nil && *p.Vision
+}
+
 func (p *BasicInferenceProvider) SystemPrompt() string {
 	return ""
 }
@@ -1,5 +1,7 @@
 package api
 
+import "strings"
+
 type MessageType string
 
 const (
@@ -13,9 +15,14 @@ const (
 type Message struct {
 	Type MessageType
 	Text string
+	// Attachments (images, PDFs, text files) provided with a user message
+	Attachments []Attachment
 
 	// ToolMessage specific fields
 	ToolName string
+
+	// Depth is the nesting level of the agent that produced the message (0 for the main agent, >0 for sub-agents)
+	Depth int
 }
 
 func NewSystemMessage(text string) Message {
@@ -45,3 +52,26 @@ func NewToolMessage(text, toolName string) Message {
 func (m *Message) Role() string {
 	return string(m.Type)
 }
+
+// Attachment is a file attached to a user message
+type Attachment struct {
+	// Name of the attached file (the path referenced in the user message)
+	Name string
+	// MimeType of the attached file (e.g. image/png, application/pdf, text/plain)
+	MimeType string
+	Data     []byte
+}
+
+// IsImage returns true if the attachment is an image
+func (a *Attachment) IsImage() bool {
+	return strings.HasPrefix(a.MimeType, "image/")
+}
+
+// IsText returns true if the attachment is a text file that can be provided inline to any model
+func (a *Attachment) IsText() bool {
+	switch a.MimeType {
+	case "application/json", "application/xml", "application/yaml", "application/x-yaml", "application/toml", "application/javascript":
+		return true
+	}
+	return strings.HasPrefix(a.MimeType, "text/")
+}
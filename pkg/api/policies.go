@@ -32,6 +32,24 @@ type ToolsProviderPolicies struct {
 	Enabled        *bool `toml:"enabled,omitempty"`
 	ReadOnly       *bool `toml:"read-only,omitempty"`
 	NonDestructive *bool `toml:"non-destructive,omitempty"`
+	// AllowedTools glob patterns of the tools that can be used (all tools if empty)
+	AllowedTools []string `toml:"allowed-tools,omitempty"`
+	// DeniedTools glob patterns of the tools that can't be used (takes precedence over AllowedTools)
+	DeniedTools []string `toml:"denied-tools,omitempty"`
+	// AllowedRoots pins the directories the filesystem and shell tools can access (replaces the configured ones)
+	AllowedRoots []string `toml:"allowed-roots,omitempty"`
+	// AdditionalRoots extends the directories the filesystem tools can access
+	AdditionalRoots []string `toml:"additional-roots,omitempty"`
+	// AllowedCommands glob patterns of the commands that the shell tools can run (all commands if empty)
+	AllowedCommands []string `toml:"allowed-commands,omitempty"`
+	// DeniedCommands glob patterns of the commands that the shell tools can't run (takes precedence over AllowedCommands)
+	DeniedCommands []string `toml:"denied-commands,omitempty"`
+	// AllowedDomains glob patterns of the domains that the HTTP tools can access (all domains if empty)
+	AllowedDomains []string `toml:"allowed-domains,omitempty"`
+	// DeniedDomains glob patterns of the domains that the HTTP tools can't access (takes precedence over AllowedDomains)
+	DeniedDomains []string `toml:"denied-domains,omitempty"`
+	// Databases pins the paths of the database files that the database tools can open (replaces the configured ones)
+	Databases []string `toml:"databases,omitempty"`
 	// Local indicates if the tool cannot connect to a remote MCP server
 	Local *bool `toml:"local,omitempty"`
 }
@@ -45,9 +63,18 @@ type ToolsPolicies struct {
 	ToolsProviderPolicies
 }
 
+// AgentsPolicies struct to define policies for the agent and its delegated sub-agents
+//
+// Agents policies are upper bounds, the configuration can only be stricter than the policies
+type AgentsPolicies struct {
+	// MaxDepth is the maximum nesting level for delegated sub-agents (0 disables sub-agents)
+	MaxDepth *int `toml:"max-depth,omitempty"`
+}
+
 type Policies struct {
 	Inferences InferencePolicies `toml:"inferences,omitempty"`
 	Tools      ToolsPolicies     `toml:"tools,omitempty"`
+	Agents     AgentsPolicies    `toml:"agents,omitempty"`
 }
 
 type PoliciesProvider interface {
@@ -27,7 +27,62 @@ type ToolsParameters struct {
 	Enabled            *bool `json:"-" toml:"enabled"`
 	ReadOnly           *bool `json:"-" toml:"read-only"`
 	DisableDestructive *bool `json:"-" toml:"disable-destructive"`
-	//Local          *bool
+	// AllowedTools glob patterns of the tools that can be used (all tools if empty)
+	AllowedTools []string `json:"-" toml:"allowed-tools"`
+	// DeniedTools glob patterns of the tools that can't be used (takes precedence over AllowedTools)
+	DeniedTools []string `json:"-" toml:"denied-tools"`
+	// MaxConcurrency is the maximum number of concurrent calls to the toolset tools (unlimited if not set or 0)
+	MaxConcurrency *int `json:"-" toml:"max-concurrency"`
+	// Timeout is the maximum duration in seconds of a call to the toolset tools (no timeout if not set or 0)
+	Timeout *int `json:"-" toml:"timeout"`
+	// ToolTimeouts are the maximum durations in seconds of the calls to specific tools (glob pattern -> seconds),
+	// they take precedence over Timeout
+	ToolTimeouts map[string]int `json:"-" toml:"tool-timeouts"`
+	// MaxOutputSize is the maximum size in bytes of a tool result provided to the model (unlimited if not set or 0),
+	// larger results are truncated and the model can page through the rest
+	MaxOutputSize *int `json:"-" toml:"max-output-size"`
+	// AllowedRoots are the directories the filesystem and shell tools can access (the current working directory if empty)
+	AllowedRoots []string `json:"-" toml:"allowed-roots"`
+	// AllowedCommands glob patterns of the commands that the shell tools can run (all commands if empty)
+	AllowedCommands []string `json:"-" toml:"allowed-commands"`
+	// DeniedCommands glob patterns of the commands that the shell tools can't run (takes precedence over AllowedCommands)
+	DeniedCommands []string `json:"-" toml:"denied-commands"`
+	// AllowedDomains glob patterns of the domains that the HTTP tools can access (all domains if empty)
+	AllowedDomains []string `json:"-" toml:"allowed-domains"`
+	// DeniedDomains glob patterns of the domains that the HTTP tools can't access (takes precedence over AllowedDomains)
+	DeniedDomains []string `json:"-" toml:"denied-domains"`
+	// Proxy is the URL of the proxy used by the HTTP tools (the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+	// variables are used if not set)
+	Proxy *string `json:"-" toml:"proxy"`
+	// Databases are the paths of the database files that the database tools can open (e.g. SQLite databases)
+	Databases []string `json:"-" toml:"databases"`
+	// InstanceUrl is the base URL of a self-managed instance of the service (e.g. https://gitlab.example.com)
+	InstanceUrl *string `json:"-" toml:"instance-url"`
+	// Toolsets are the toolsets (groups of tools) enabled in the MCP server of the provider (provider defaults if empty)
+	Toolsets []string `json:"-" toml:"toolsets"`
+	// Local runs the MCP server of the provider locally instead of connecting to its remote (hosted) MCP server
+	Local *bool `json:"-" toml:"local"`
+}
+
+// DuplicateTool is a tool name exposed by more than one toolset.
+// The tools don't collide since their names are prefixed with the toolset name, but the model might confuse them.
+type DuplicateTool struct {
+	Name     string   `json:"name"`
+	Toolsets []string `json:"toolsets"`
+}
+
+// FilteredTool is a tool that is not available because it's filtered out by the toolset parameters
+type FilteredTool struct {
+	Toolset string `json:"toolset"`
+	Name    string `json:"name"`
+	Reason  string `json:"reason"`
+}
+
+// EmptyToolset is an available toolset with all of its tools filtered out by the toolset parameters
+// (e.g. an MCP server that doesn't annotate its tools with a read-only policy)
+type EmptyToolset struct {
+	Toolset string `json:"toolset"`
+	Reason  string `json:"reason"`
 }
 
 type BasicToolsProvider struct {
@@ -71,25 +126,66 @@ type BasicToolsAttributes struct {
 	BasicFeatureAttributes
 }
 
+// Tool is a native tool of a ToolsProvider.
+//
+// Errors returned by the Function are unexpected failures that abort the agent run. Problems the model can correct
+// (invalid arguments, access denied by the toolset parameters, failed commands or requests...) must be returned as the
+// tool result with a nil error instead, or as a ToolResultError, so that the model can fix the call in the next step.
 type Tool struct {
 	Name        string
 	Description string
 	// Parameters in map format (if ParametersSchema is not set)
 	Parameters map[string]ToolParameter
-	Function   func(args map[string]interface{}) (string, error)
+	// ReadOnly tools don't modify their environment, they're the only tools available for read-only toolsets
+	ReadOnly bool
+	// Destructive tools may perform destructive updates (overwrite or delete existing data), they're hidden if
+	// destructive tools are disabled and the user is asked for approval before they're called
+	Destructive bool
+	Function    func(args map[string]interface{}) (string, error)
+}
+
+// ToolResultError is an error that the tool Function provides to the model as its result (see Tool).
+// The tool invocation converts it (even if wrapped) into the tool result with a nil error.
+type ToolResultError struct {
+	Message string
+}
+
+func (e *ToolResultError) Error() string {
+	return e.Message
+}
+
+// NewDeniedError returns a ToolResultError for an operation (e.g. "Access", "Command") denied by the toolset parameters
+func NewDeniedError(operation, reason string) *ToolResultError {
+	return &ToolResultError{Message: fmt.Sprintf("%s denied: %s.", operation, reason)}
 }
 
 type ToolParameterType string
 
 const (
-	String ToolParameterType = "string"
-	// TODO: add more types as needed
+	String  ToolParameterType = "string"
+	Integer ToolParameterType = "integer"
+	Number  ToolParameterType = "number"
+	Boolean ToolParameterType = "boolean"
+	Array   ToolParameterType = "array"
+	Object  ToolParameterType = "object"
 )
 
+// ToolParameter describes a tool parameter (JSON Schema)
 type ToolParameter struct {
 	Type        ToolParameterType
 	Description string
 	Required    bool
+	// Enum values allowed for the parameter
+	Enum []string
+	// Items describes the elements of an Array parameter
+	Items *ToolParameter
+	// Properties describes the fields of an Object parameter
+	Properties map[string]ToolParameter
+	// Default value used by the tool when the parameter is not provided
+	Default any
+	// Minimum and Maximum (inclusive) values for an Integer or Number parameter
+	Minimum *float64
+	Maximum *float64
 }
 
 type McpType int
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -81,3 +82,21 @@ func (s *McpTypeTestSuite) TestUnmarshalJSON() {
 func TestMcpType(t *testing.T) {
 	suite.Run(t, new(McpTypeTestSuite))
 }
+
+type ToolResultErrorTestSuite struct {
+	suite.Suite
+}
+
+func (s *ToolResultErrorTestSuite) TestNewDeniedError() {
+	err := NewDeniedError("Access", "the domain example.com is not allowed")
+	s.Equal("Access denied: the domain example.com is not allowed.", err.Error())
+	s.Run("can be unwrapped from wrapped errors", func() {
+		var resultError *ToolResultError
+		s.True(errors.As(fmt.Errorf("redirect failed: %w", err), &resultError))
+		s.Same(err, resultError)
+	})
+}
+
+func TestToolResultError(t *testing.T) {
+	suite.Run(t, new(ToolResultErrorTestSuite))
+}
@@ -5,6 +5,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/manusa/ai-cli/pkg/ai"
 	"github.com/manusa/ai-cli/pkg/api"
 	"github.com/manusa/ai-cli/pkg/config"
 	"github.com/manusa/ai-cli/pkg/features"
@@ -15,13 +16,17 @@ import (
 )
 
 type DiscoverCmdOptions struct {
-	outputFormat string
-	mcpConfig    string
-	policiesFile string
+	outputFormat  string
+	mcpConfig     string
+	policiesFile  string
+	filteredTools bool
+	inspectTools  bool
 }
 
 var (
 	editors = []string{"cursor"}
+	// inspectToolsFunc lists the filtered out and duplicate tools, and the empty toolsets, of the available tools providers (var can be overridden in tests)
+	inspectToolsFunc = ai.InspectTools
 )
 
 func NewDiscoverCmdOptions() *DiscoverCmdOptions {
@@ -55,6 +60,8 @@ func NewDiscoverCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&o.outputFormat, "output", "o", "json", "Output format (json, text)")
 	cmd.Flags().StringVar(&o.mcpConfig, "mcp-config", "", fmt.Sprintf("Configure editor MCP config (%s). This option replaces the normal output", strings.Join(editors, ", ")))
 	cmd.Flags().StringVar(&o.policiesFile, "policies", "", "Policies file to use")
+	cmd.Flags().BoolVar(&o.filteredTools, "filtered-tools", false, "Include the tools filtered out by the configuration and policies (starts the MCP servers of the available tools providers)")
+	cmd.Flags().BoolVar(&o.inspectTools, "inspect-tools", false, "Warn about duplicate tool names and toolsets without tools (starts the MCP servers of the available tools providers)")
 
 	return cmd
 }
@@ -101,6 +108,16 @@ func (o *DiscoverCmdOptions) Run(cmd *cobra.Command) error {
 		return mcpconfig.Save(mcpConfigProvider, discoveredFeatures.Tools)
 	}
 
+	// The MCP servers of the available tools providers are only started on demand to list their tools
+	if o.filteredTools || o.inspectTools {
+		inspection := inspectToolsFunc(cmd.Context(), discoveredFeatures.Tools)
+		discoveredFeatures.DuplicateTools = inspection.DuplicateTools
+		discoveredFeatures.EmptyToolsets = inspection.EmptyToolsets
+		if o.filteredTools {
+			discoveredFeatures.FilteredTools = inspection.FilteredTools
+		}
+	}
+
 	switch o.outputFormat {
 	case "json":
 		jsonString, err := discoveredFeatures.ToJSON()
@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/ai"
+	"github.com/manusa/ai-cli/pkg/api"
 	"github.com/manusa/ai-cli/pkg/config"
 	"github.com/manusa/ai-cli/pkg/inference/ollama"
 	"github.com/manusa/ai-cli/pkg/keyring"
@@ -62,24 +66,48 @@ func (s *DiscoverTestSuite) TestOutputText() {
 			"    Reason: ramalama is not installed\n" +
 			"Available Tools Providers:\n" +
 			"  - fs\n" +
-			"    Description: Provides access to the local filesystem, allowing listing of files and directories.\n" +
+			"    Description: Provides access to the local filesystem, allowing listing, reading, searching and editing files and directories.\n" +
 			"    Reason: filesystem is accessible\n" +
+			"  - http\n" +
+			"    Description: Provides lightweight web access, allowing fetching web pages (converted to markdown) and calling HTTP APIs.\n" +
+			"    Reason: HTTP requests can be performed\n" +
 			"Not Available Tools Providers:\n" +
 			"  - browsers\n" +
 			"    Description: Provides access to browser metadata such as bookmarks, search history, and so on\n" +
 			"    Reason: no browsers detected\n" +
+			"  - containers\n" +
+			"    Description: Provides access to the local container runtime (podman or docker), allowing inspection of the containers, their logs and resource usage, and managing their lifecycle.\n" +
+			"    Reason: no container runtime found (podman, docker)\n" +
+			"  - git\n" +
+			"    Description: Provides access to the git repository of the current directory, allowing inspection of its status, changes and history, and committing changes.\n" +
+			"    Reason: git is not installed\n" +
 			"  - github\n" +
 			"    Description: Provides access to GitHub Platform. Provides the ability to to read repositories and code files, manage issues and PRs, analyze code, and automate workflows.\n" +
 			"    Reason: GITHUB_PERSONAL_ACCESS_TOKEN is not set\n" +
+			"  - gitlab\n" +
+			"    Description: Provides access to GitLab (gitlab.com or self-managed instances). Provides the ability to read projects and code files, manage issues and merge requests, and inspect pipelines.\n" +
+			"    Reason: GITLAB_PERSONAL_ACCESS_TOKEN is not set\n" +
 			"  - kubernetes\n" +
 			"    Description: Provides access to Kubernetes clusters, allowing management and interaction with cluster resources.\n" +
 			"    Reason: no suitable MCP settings found for the Kubernetes MCP server\n" +
+			"  - mysql\n" +
+			"    Description: Provides access to a MySQL or MariaDB database, allowing execution of SQL queries and retrieval of data.\n" +
+			"    Reason: no suitable MCP settings found for the MySQL MCP server\n" +
 			"  - playwright\n" +
 			"    Description: Enables web browsing capabilities through Playwright. Opening web pages, opening URLs, interacting with elements inside the browser, extracting snapshots, and scraping information from web pages. Support for multiple tabs and many other browser options\n" +
 			"    Reason: npx command not found\n" +
 			"  - postgresql\n" +
 			"    Description: Provides access to a PostgreSQL database, allowing execution of SQL queries and retrieval of data.\n" +
-			"    Reason: no suitable MCP settings found for the PostgreSQL MCP server\n"
+			"    Reason: no suitable MCP settings found for the PostgreSQL MCP server\n" +
+			"  - redis\n" +
+			"    Description: Provides access to a Redis server, allowing inspection of its keys and values, server information and slow queries.\n" +
+			"    Reason: REDIS_URL is not set\n" +
+			"  - shell\n" +
+			"    Description: Provides access to a shell to run commands on the local machine.\n" +
+			"    Reason: sh is not available\n" +
+			"  - sqlite\n" +
+			"    Description: Provides access to local SQLite database files, allowing inspection of their schema and execution of SQL queries.\n" +
+			"    Reason: no SQLite databases configured\n"
 		s.Equal(expectedOutput, output, "Expected output does not match")
 	})
 }
@@ -101,13 +129,21 @@ func (s *DiscoverTestSuite) TestOutputJson() {
 			`{"description":"Ramalama local inference provider","name":"ramalama","local":true,"public":false,"reason":"ramalama is not installed","models":null}],` +
 			`"inference":null,` +
 			`"tools":[` +
-			`{"description":"Provides access to the local filesystem, allowing listing of files and directories.","name":"fs","reason":"filesystem is accessible"}],` +
+			`{"description":"Provides access to the local filesystem, allowing listing, reading, searching and editing files and directories.","name":"fs","reason":"filesystem is accessible"},` +
+			`{"description":"Provides lightweight web access, allowing fetching web pages (converted to markdown) and calling HTTP APIs.","name":"http","reason":"HTTP requests can be performed"}],` +
 			`"toolsNotAvailable":[` +
 			`{"description":"Provides access to browser metadata such as bookmarks, search history, and so on","name":"browsers","reason":"no browsers detected"},` +
+			`{"description":"Provides access to the local container runtime (podman or docker), allowing inspection of the containers, their logs and resource usage, and managing their lifecycle.","name":"containers","reason":"no container runtime found (podman, docker)"},` +
+			`{"description":"Provides access to the git repository of the current directory, allowing inspection of its status, changes and history, and committing changes.","name":"git","reason":"git is not installed"},` +
 			`{"description":"Provides access to GitHub Platform. Provides the ability to to read repositories and code files, manage issues and PRs, analyze code, and automate workflows.","name":"github","reason":"GITHUB_PERSONAL_ACCESS_TOKEN is not set"},` +
+			`{"description":"Provides access to GitLab (gitlab.com or self-managed instances). Provides the ability to read projects and code files, manage issues and merge requests, and inspect pipelines.","name":"gitlab","reason":"GITLAB_PERSONAL_ACCESS_TOKEN is not set"},` +
 			`{"description":"Provides access to Kubernetes clusters, allowing management and interaction with cluster resources.","name":"kubernetes","reason":"no suitable MCP settings found for the Kubernetes MCP server"},` +
+			`{"description":"Provides access to a MySQL or MariaDB database, allowing execution of SQL queries and retrieval of data.","name":"mysql","reason":"no suitable MCP settings found for the MySQL MCP server"},` +
 			`{"description":"Enables web browsing capabilities through Playwright. Opening web pages, opening URLs, interacting with elements inside the browser, extracting snapshots, and scraping information from web pages. Support for multiple tabs and many other browser options","name":"playwright","reason":"npx command not found"},` +
-			`{"description":"Provides access to a PostgreSQL database, allowing execution of SQL queries and retrieval of data.","name":"postgresql","reason":"no suitable MCP settings found for the PostgreSQL MCP server"}` +
+			`{"description":"Provides access to a PostgreSQL database, allowing execution of SQL queries and retrieval of data.","name":"postgresql","reason":"no suitable MCP settings found for the PostgreSQL MCP server"},` +
+			`{"description":"Provides access to a Redis server, allowing inspection of its keys and values, server information and slow queries.","name":"redis","reason":"REDIS_URL is not set"},` +
+			`{"description":"Provides access to a shell to run commands on the local machine.","name":"shell","reason":"sh is not available"},` +
+			`{"description":"Provides access to local SQLite database files, allowing inspection of their schema and execution of SQL queries.","name":"sqlite","reason":"no SQLite databases configured"}` +
 			`]}`
 		s.JSONEq(expectedOutput, output, "Expected JSON output does not match")
 	})
@@ -140,6 +176,110 @@ func (s *DiscoverTestSuite) TestMcpConfigEmptyValue() {
 	})
 }
 
+func (s *DiscoverTestSuite) TestFilteredTools() {
+	policiesFile := filepath.Join(s.T().TempDir(), "policies.toml")
+	s.Require().NoError(os.WriteFile(policiesFile, []byte("[tools.provider.fs]\ndenied-tools = [\"file_*\"]\n"), 0644))
+	s.Run("Outputs filtered tools with reason in text", func() {
+		s.rootCmd.SetArgs([]string{"discover", "--output", "text", "--filtered-tools", "--policies", policiesFile})
+		output, err := captureOutput(s.rootCmd.Execute)
+		s.NoErrorf(err, "Error executing command: %v", err)
+		s.Contains(output, "Filtered Tools:\n"+
+			"  - fs_file_list (fs)\n"+
+			"    Reason: the tool is denied by the 'file_*' pattern\n")
+	})
+	s.Run("Outputs filtered tools with reason in JSON", func() {
+		s.rootCmd = NewAiCli()
+		s.rootCmd.SetArgs([]string{"discover", "--output", "json", "--filtered-tools", "--policies", policiesFile})
+		output, err := captureOutput(s.rootCmd.Execute)
+		s.NoErrorf(err, "Error executing command: %v", err)
+		s.Contains(output, `"filteredTools": [
+    {
+      "toolset": "fs",
+      "name": "fs_file_list",
+      "reason": "the tool is denied by the 'file_*' pattern"
+    },`)
+	})
+	s.Run("Filtered tools are not listed by default", func() {
+		s.rootCmd = NewAiCli()
+		s.rootCmd.SetArgs([]string{"discover", "--output", "text", "--policies", policiesFile})
+		output, err := captureOutput(s.rootCmd.Execute)
+		s.NoErrorf(err, "Error executing command: %v", err)
+		s.NotContains(output, "Filtered Tools:")
+	})
+}
+
+func (s *DiscoverTestSuite) TestInspectTools() {
+	originalInspectToolsFunc := inspectToolsFunc
+	defer func() { inspectToolsFunc = originalInspectToolsFunc }()
+	inspected := false
+	inspectToolsFunc = func(_ context.Context, _ []api.ToolsProvider) ai.ToolsInspection {
+		inspected = true
+		return ai.ToolsInspection{
+			FilteredTools:  []api.FilteredTool{{Toolset: "fs", Name: "fs_file_list", Reason: "denied"}},
+			DuplicateTools: []api.DuplicateTool{{Name: "search", Toolsets: []string{"github", "gitlab"}}},
+		}
+	}
+	s.Run("Tools are not inspected by default", func() {
+		s.rootCmd.SetArgs([]string{"discover", "--output", "text"})
+		output, err := captureOutput(s.rootCmd.Execute)
+		s.NoErrorf(err, "Error executing command: %v", err)
+		s.False(inspected, "Expected the MCP servers not to be started")
+		s.NotContains(output, "Warning:")
+	})
+	s.Run("Warns about duplicate tools with --inspect-tools in text", func() {
+		s.rootCmd = NewAiCli()
+		s.rootCmd.SetArgs([]string{"discover", "--output", "text", "--inspect-tools"})
+		output, err := captureOutput(s.rootCmd.Execute)
+		s.NoErrorf(err, "Error executing command: %v", err)
+		s.Contains(output, "Warning: tool 'search' is exposed by multiple toolsets (github, gitlab), it is available as github_search, gitlab_search\n")
+		s.NotContains(output, "Filtered Tools:")
+	})
+	s.Run("Lists duplicate tools with --inspect-tools in JSON", func() {
+		s.rootCmd = NewAiCli()
+		s.rootCmd.SetArgs([]string{"discover", "--output", "json", "--inspect-tools"})
+		output, err := captureOutput(s.rootCmd.Execute)
+		s.NoErrorf(err, "Error executing command: %v", err)
+		s.Contains(output, `"duplicateTools": [
+    {
+      "name": "search",
+      "toolsets": [
+        "github",
+        "gitlab"
+      ]
+    }
+  ]`)
+		s.NotContains(output, `"filteredTools"`)
+	})
+}
+
+func (s *DiscoverTestSuite) TestEmptyToolsets() {
+	originalInspectToolsFunc := inspectToolsFunc
+	defer func() { inspectToolsFunc = originalInspectToolsFunc }()
+	inspectToolsFunc = func(_ context.Context, _ []api.ToolsProvider) ai.ToolsInspection {
+		return ai.ToolsInspection{
+			EmptyToolsets: []api.EmptyToolset{{Toolset: "playwright", Reason: "all of its tools are filtered out (the 'playwright' toolset is read-only)"}},
+		}
+	}
+	s.Run("Warns about empty toolsets in text", func() {
+		s.rootCmd.SetArgs([]string{"discover", "--output", "text", "--inspect-tools"})
+		output, err := captureOutput(s.rootCmd.Execute)
+		s.NoErrorf(err, "Error executing command: %v", err)
+		s.Contains(output, "Warning: toolset 'playwright' provides no tools, all of its tools are filtered out (the 'playwright' toolset is read-only)\n")
+	})
+	s.Run("Lists empty toolsets in JSON", func() {
+		s.rootCmd = NewAiCli()
+		s.rootCmd.SetArgs([]string{"discover", "--output", "json", "--inspect-tools"})
+		output, err := captureOutput(s.rootCmd.Execute)
+		s.NoErrorf(err, "Error executing command: %v", err)
+		s.Contains(output, `"emptyToolsets": [
+    {
+      "toolset": "playwright",
+      "reason": "all of its tools are filtered out (the 'playwright' toolset is read-only)"
+    }
+  ]`)
+	})
+}
+
 func TestDiscover(t *testing.T) {
 	suite.Run(t, new(DiscoverTestSuite))
 }
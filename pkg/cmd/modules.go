@@ -7,11 +7,19 @@ import (
 	_ "github.com/manusa/ai-cli/pkg/inference/ramalama"
 
 	_ "github.com/manusa/ai-cli/pkg/tools/browsers"
+	_ "github.com/manusa/ai-cli/pkg/tools/containers"
 	_ "github.com/manusa/ai-cli/pkg/tools/fs"
+	_ "github.com/manusa/ai-cli/pkg/tools/git"
 	_ "github.com/manusa/ai-cli/pkg/tools/github"
+	_ "github.com/manusa/ai-cli/pkg/tools/gitlab"
+	_ "github.com/manusa/ai-cli/pkg/tools/http"
 	_ "github.com/manusa/ai-cli/pkg/tools/kubernetes"
+	_ "github.com/manusa/ai-cli/pkg/tools/mysql"
 	_ "github.com/manusa/ai-cli/pkg/tools/playwright"
 	_ "github.com/manusa/ai-cli/pkg/tools/postgresql"
+	_ "github.com/manusa/ai-cli/pkg/tools/redis"
+	_ "github.com/manusa/ai-cli/pkg/tools/shell"
+	_ "github.com/manusa/ai-cli/pkg/tools/sqlite"
 
 	_ "github.com/feloy/browsers-mcp-server/pkg/browsers/chrome"
 	_ "github.com/feloy/browsers-mcp-server/pkg/browsers/firefox"
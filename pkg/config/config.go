@@ -1,11 +1,20 @@
 package config
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/manusa/ai-cli/pkg/api"
 )
 
 const (
 	DefaultInferenceEnabled = true
+	DefaultAgentsMaxDepth   = 1
+	DefaultAgentsMaxSteps   = 20
+	// DefaultToolsTimeout is the maximum duration in seconds of a tool call
+	DefaultToolsTimeout = 300
+	// DefaultToolsMaxOutputSize is the maximum size in bytes of a tool result provided to the model
+	DefaultToolsMaxOutputSize = 32 * 1024
 )
 
 type InferenceConfig struct {
@@ -25,8 +34,9 @@ type ToolsConfig struct {
 }
 
 type Config struct {
-	InferenceConfig InferenceConfig `toml:"inferences,omitempty"`
-	toolsConfig     ToolsConfig     `toml:"tools,omitempty"`
+	InferenceConfig InferenceConfig      `toml:"inferences,omitempty"`
+	toolsConfig     ToolsConfig          `toml:"tools,omitempty"`
+	agentsConfig    api.AgentsParameters `toml:"agents,omitempty"`
 
 	policies *api.Policies // TODO: should be removed in favor of ToolsConfig and InferenceConfig above
 }
@@ -54,9 +64,21 @@ func New() *Config {
 				// By default, tools are destructive and read-write
 				ReadOnly:           ptr(false),
 				DisableDestructive: ptr(false),
+				Timeout:            ptr(DefaultToolsTimeout),
+				MaxOutputSize:      ptr(DefaultToolsMaxOutputSize),
 			},
 			Provider: make(map[string]api.ToolsParameters),
 		},
+		agentsConfig: api.AgentsParameters{
+			MaxDepth: ptr(DefaultAgentsMaxDepth),
+			MaxSteps: ptr(DefaultAgentsMaxSteps),
+			// By default, the model is asked to enable the toolsets explicitly
+			AutoEnableToolsets: ptr(false),
+			// By default, the user is asked for approval before calling destructive tools
+			RequireApproval: ptr(true),
+			// By default, tools are called sequentially in the order requested by the model
+			ParallelToolCalls: ptr(false),
+		},
 	}
 }
 
@@ -78,6 +100,9 @@ func (c *Config) InferenceParameters(inferenceProviderName string) api.Inference
 		if params.Enabled != nil {
 			mergedParameters.Enabled = params.Enabled
 		}
+		if params.Vision != nil {
+			mergedParameters.Vision = params.Vision
+		}
 	}
 	return mergedParameters
 }
@@ -102,10 +127,64 @@ func (c *Config) ToolsParameters(toolProviderName string) api.ToolsParameters {
 		if params.DisableDestructive != nil {
 			mergedParameters.DisableDestructive = params.DisableDestructive
 		}
+		if len(params.AllowedTools) > 0 {
+			mergedParameters.AllowedTools = params.AllowedTools
+		}
+		if params.MaxConcurrency != nil {
+			mergedParameters.MaxConcurrency = params.MaxConcurrency
+		}
+		if params.Timeout != nil {
+			mergedParameters.Timeout = params.Timeout
+		}
+		if len(params.ToolTimeouts) > 0 {
+			// Provider tool timeouts are added to (or override) the global ones
+			toolTimeouts := maps.Clone(mergedParameters.ToolTimeouts)
+			if toolTimeouts == nil {
+				toolTimeouts = make(map[string]int)
+			}
+			maps.Copy(toolTimeouts, params.ToolTimeouts)
+			mergedParameters.ToolTimeouts = toolTimeouts
+		}
+		if params.MaxOutputSize != nil {
+			mergedParameters.MaxOutputSize = params.MaxOutputSize
+		}
+		if len(params.AllowedRoots) > 0 {
+			mergedParameters.AllowedRoots = params.AllowedRoots
+		}
+		if len(params.AllowedCommands) > 0 {
+			mergedParameters.AllowedCommands = params.AllowedCommands
+		}
+		if len(params.AllowedDomains) > 0 {
+			mergedParameters.AllowedDomains = params.AllowedDomains
+		}
+		if params.Proxy != nil {
+			mergedParameters.Proxy = params.Proxy
+		}
+		if len(params.Databases) > 0 {
+			mergedParameters.Databases = params.Databases
+		}
+		if params.InstanceUrl != nil {
+			mergedParameters.InstanceUrl = params.InstanceUrl
+		}
+		if len(params.Toolsets) > 0 {
+			mergedParameters.Toolsets = params.Toolsets
+		}
+		if params.Local != nil {
+			mergedParameters.Local = params.Local
+		}
+		// Denied tools are accumulated, a tool denied globally can't be allowed by a provider
+		mergedParameters.DeniedTools = appendMissing(mergedParameters.DeniedTools, params.DeniedTools...)
+		mergedParameters.DeniedCommands = appendMissing(mergedParameters.DeniedCommands, params.DeniedCommands...)
+		mergedParameters.DeniedDomains = appendMissing(mergedParameters.DeniedDomains, params.DeniedDomains...)
 	}
 	return mergedParameters
 }
 
+// AgentsParameters returns the configuration parameters for the agent and its delegated sub-agents
+func (c *Config) AgentsParameters() api.AgentsParameters {
+	return c.agentsConfig
+}
+
 func (c *Config) Enforce(policies *api.Policies) {
 	if policies == nil {
 		return
@@ -120,6 +199,7 @@ func (c *Config) Enforce(policies *api.Policies) {
 		c.InferenceConfig.Provider[providerName] = mergeInferencesPolicies(policies.Inferences.InferenceProviderPolicies, providerParameters)
 	}
 	for providerName, providerConfig := range c.toolsConfig.Provider {
+		providerConfig.AllowedRoots = c.inheritedRoots(providerConfig)
 		c.toolsConfig.Provider[providerName] = mergeToolsPolicies(policies.Tools.ToolsProviderPolicies, providerConfig)
 	}
 
@@ -136,9 +216,20 @@ func (c *Config) Enforce(policies *api.Policies) {
 		if !ok {
 			originalParams = api.ToolsParameters{}
 		}
+		originalParams.AllowedRoots = c.inheritedRoots(originalParams)
 		c.toolsConfig.Provider[providerName] = mergeToolsPolicies(providerPolicies, originalParams)
 	}
 
+	c.agentsConfig = mergeAgentsPolicies(policies.Agents, c.agentsConfig)
+}
+
+// inheritedRoots returns the provider allowed roots or the global ones if the provider doesn't define them.
+// Policies extending the allowed roots of a provider must extend the global ones, since the provider roots replace them.
+func (c *Config) inheritedRoots(providerParameters api.ToolsParameters) []string {
+	if len(providerParameters.AllowedRoots) > 0 {
+		return providerParameters.AllowedRoots
+	}
+	return slices.Clone(c.toolsConfig.AllowedRoots)
 }
 
 func mergeInferencesPolicies(inferencesPolicies api.InferenceProviderPolicies, inferenceParameters api.InferenceParameters) api.InferenceParameters {
@@ -160,13 +251,56 @@ func mergeToolsPolicies(toolsPolicies api.ToolsProviderPolicies, toolsParameters
 	if toolsPolicies.NonDestructive != nil {
 		toolsParameters.DisableDestructive = toolsPolicies.NonDestructive
 	}
-	//nolint:staticcheck
-	if toolsPolicies.Local != nil {
-		// TODO: I don't understand what policies.Tools.Local is meant for
+	if len(toolsPolicies.AllowedTools) > 0 {
+		toolsParameters.AllowedTools = toolsPolicies.AllowedTools
+	}
+	if len(toolsPolicies.DeniedTools) > 0 {
+		// Policies can only add denied tools to the configuration
+		toolsParameters.DeniedTools = appendMissing(slices.Clone(toolsParameters.DeniedTools), toolsPolicies.DeniedTools...)
+	}
+	if len(toolsPolicies.AllowedCommands) > 0 {
+		toolsParameters.AllowedCommands = toolsPolicies.AllowedCommands
+	}
+	if len(toolsPolicies.DeniedCommands) > 0 {
+		// Policies can only add denied commands to the configuration
+		toolsParameters.DeniedCommands = appendMissing(slices.Clone(toolsParameters.DeniedCommands), toolsPolicies.DeniedCommands...)
+	}
+	if len(toolsPolicies.AllowedDomains) > 0 {
+		toolsParameters.AllowedDomains = toolsPolicies.AllowedDomains
+	}
+	if len(toolsPolicies.DeniedDomains) > 0 {
+		// Policies can only add denied domains to the configuration
+		toolsParameters.DeniedDomains = appendMissing(slices.Clone(toolsParameters.DeniedDomains), toolsPolicies.DeniedDomains...)
+	}
+	if len(toolsPolicies.Databases) > 0 {
+		toolsParameters.Databases = toolsPolicies.Databases
+	}
+	if len(toolsPolicies.AllowedRoots) > 0 {
+		toolsParameters.AllowedRoots = toolsPolicies.AllowedRoots
+	}
+	if len(toolsPolicies.AdditionalRoots) > 0 {
+		allowedRoots := slices.Clone(toolsParameters.AllowedRoots)
+		if len(allowedRoots) == 0 {
+			// Extend the default root (the current working directory)
+			allowedRoots = []string{"."}
+		}
+		toolsParameters.AllowedRoots = appendMissing(allowedRoots, toolsPolicies.AdditionalRoots...)
+	}
+	if toolsPolicies.Local != nil && *toolsPolicies.Local {
+		// Policies can only prevent the connection to remote MCP servers, the configuration is preserved otherwise
+		toolsParameters.Local = toolsPolicies.Local
 	}
 	return toolsParameters
 }
 
+func mergeAgentsPolicies(agentsPolicies api.AgentsPolicies, agentsParameters api.AgentsParameters) api.AgentsParameters {
+	// Policies are an upper bound, the configuration is preserved only if it's stricter
+	if agentsPolicies.MaxDepth != nil && (agentsParameters.MaxDepth == nil || *agentsParameters.MaxDepth > *agentsPolicies.MaxDepth) {
+		agentsParameters.MaxDepth = agentsPolicies.MaxDepth
+	}
+	return agentsParameters
+}
+
 func (c *Config) IsInferenceProviderEnabled(feature api.Feature[api.InferenceAttributes]) bool {
 	// TODO: relying only on *c.InferenceParameters(feature.Attributes().Name()).Enabled
 	//       won't work even if we consider policies.
@@ -207,6 +341,16 @@ func (c *Config) IsToolsProviderEnabled(feature api.Feature[api.ToolsAttributes]
 	return *c.ToolsParameters(feature.Attributes().Name()).Enabled
 }
 
+// appendMissing appends the values that are not already contained in the slice
+func appendMissing[T comparable](slice []T, values ...T) []T {
+	for _, value := range values {
+		if !slices.Contains(slice, value) {
+			slice = append(slice, value)
+		}
+	}
+	return slice
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
@@ -213,6 +213,168 @@ non-destructive = false
 	})
 }
 
+func (s *ConfigEnforceTestSuite) TestToolsAllowedAndDeniedToolsPolicies() {
+	s.baseConfig.toolsConfig.AllowedTools = []string{"*"}
+	s.baseConfig.toolsConfig.DeniedTools = []string{"*_delete"}
+	s.baseConfig.Enforce(test.Must(policies.ReadToml(`
+[tools]
+denied-tools = ["*_exec"]
+[tools.provider.github]
+allowed-tools = ["*_issue*"]
+denied-tools = ["merge_pull_request"]
+`)))
+	s.Run("global denied-tools policies are added to the configuration", func() {
+		s.Equal([]string{"*_delete", "*_exec"}, s.baseConfig.ToolsParameters("kubernetes").DeniedTools)
+	})
+	s.Run("global allowed-tools configuration is preserved if not set by policies", func() {
+		s.Equal([]string{"*"}, s.baseConfig.ToolsParameters("kubernetes").AllowedTools)
+	})
+	s.Run("provider allowed-tools policies override configuration", func() {
+		s.Equal([]string{"*_issue*"}, s.baseConfig.ToolsParameters("github").AllowedTools)
+	})
+	s.Run("provider denied-tools policies are added to the global ones", func() {
+		s.Equal([]string{"*_delete", "*_exec", "merge_pull_request"}, s.baseConfig.ToolsParameters("github").DeniedTools)
+	})
+}
+
+func (s *ConfigEnforceTestSuite) TestToolsAllowedAndDeniedCommandsPolicies() {
+	s.baseConfig.toolsConfig.AllowedCommands = []string{"*"}
+	s.baseConfig.toolsConfig.DeniedCommands = []string{"rm *"}
+	s.baseConfig.Enforce(test.Must(policies.ReadToml(`
+[tools]
+denied-commands = ["sudo *"]
+[tools.provider.shell]
+allowed-commands = ["make *"]
+`)))
+	s.Run("global denied-commands policies are added to the configuration", func() {
+		s.Equal([]string{"rm *", "sudo *"}, s.baseConfig.ToolsParameters("shell").DeniedCommands)
+	})
+	s.Run("provider allowed-commands policies override configuration", func() {
+		s.Equal([]string{"make *"}, s.baseConfig.ToolsParameters("shell").AllowedCommands)
+	})
+}
+
+func (s *ConfigEnforceTestSuite) TestToolsAllowedAndDeniedDomainsPolicies() {
+	s.baseConfig.toolsConfig.AllowedDomains = []string{"*"}
+	s.baseConfig.toolsConfig.DeniedDomains = []string{"localhost"}
+	s.baseConfig.Enforce(test.Must(policies.ReadToml(`
+[tools]
+denied-domains = ["*.internal"]
+[tools.provider.http]
+allowed-domains = ["*.example.com"]
+`)))
+	s.Run("global denied-domains policies are added to the configuration", func() {
+		s.Equal([]string{"localhost", "*.internal"}, s.baseConfig.ToolsParameters("http").DeniedDomains)
+	})
+	s.Run("provider allowed-domains policies override configuration", func() {
+		s.Equal([]string{"*.example.com"}, s.baseConfig.ToolsParameters("http").AllowedDomains)
+	})
+}
+
+func (s *ConfigEnforceTestSuite) TestToolsLocalPolicies() {
+	s.Run("local policies override configuration", func() {
+		cfg := New()
+		cfg.toolsConfig.Provider["github"] = api.ToolsParameters{Local: ptr(false)}
+		cfg.Enforce(test.Must(policies.ReadToml(`
+[tools]
+local = true
+`)))
+		s.Equal(ptr(true), cfg.ToolsParameters("github").Local)
+		s.Equal(ptr(true), cfg.ToolsParameters("other").Local)
+	})
+	s.Run("provider local policies override configuration", func() {
+		cfg := New()
+		cfg.Enforce(test.Must(policies.ReadToml(`
+[tools.provider.github]
+local = true
+`)))
+		s.Equal(ptr(true), cfg.ToolsParameters("github").Local)
+		s.Nil(cfg.ToolsParameters("other").Local)
+	})
+	s.Run("local = false policies preserve the configuration", func() {
+		cfg := New()
+		cfg.toolsConfig.Provider["github"] = api.ToolsParameters{Local: ptr(true)}
+		cfg.Enforce(test.Must(policies.ReadToml(`
+[tools]
+local = false
+`)))
+		s.Equal(ptr(true), cfg.ToolsParameters("github").Local)
+	})
+}
+
+func (s *ConfigEnforceTestSuite) TestToolsAllowedRootsPolicies() {
+	s.Run("additional-roots policies extend the default root", func() {
+		cfg := New()
+		cfg.Enforce(test.Must(policies.ReadToml(`
+[tools]
+additional-roots = ["/tmp"]
+`)))
+		s.Equal([]string{".", "/tmp"}, cfg.ToolsParameters("fs").AllowedRoots)
+	})
+	s.Run("additional-roots policies extend the configured roots", func() {
+		cfg := New()
+		cfg.toolsConfig.AllowedRoots = []string{"/projects"}
+		cfg.Enforce(test.Must(policies.ReadToml(`
+[tools]
+additional-roots = ["/tmp"]
+[tools.provider.fs]
+additional-roots = ["/data"]
+`)))
+		s.Equal([]string{"/projects", "/tmp"}, cfg.ToolsParameters("kubernetes").AllowedRoots)
+		s.Equal([]string{"/projects", "/tmp", "/data"}, cfg.ToolsParameters("fs").AllowedRoots)
+	})
+	s.Run("allowed-roots policies pin the roots", func() {
+		cfg := New()
+		cfg.toolsConfig.AllowedRoots = []string{"/"}
+		cfg.toolsConfig.Provider["fs"] = api.ToolsParameters{AllowedRoots: []string{"/home"}}
+		cfg.Enforce(test.Must(policies.ReadToml(`
+[tools.provider.fs]
+allowed-roots = ["/projects"]
+`)))
+		s.Equal([]string{"/projects"}, cfg.ToolsParameters("fs").AllowedRoots)
+	})
+}
+
+func (s *ConfigEnforceTestSuite) TestToolsDatabasesPolicies() {
+	s.Run("databases policies pin the databases", func() {
+		cfg := New()
+		cfg.toolsConfig.Provider["sqlite"] = api.ToolsParameters{Databases: []string{"app.db"}}
+		cfg.Enforce(test.Must(policies.ReadToml(`
+[tools.provider.sqlite]
+databases = ["/data/shared.db"]
+`)))
+		s.Equal([]string{"/data/shared.db"}, cfg.ToolsParameters("sqlite").Databases)
+		s.Empty(cfg.ToolsParameters("fs").Databases)
+	})
+}
+
+func (s *ConfigEnforceTestSuite) TestAgentsPolicies() {
+	s.Run("default agents parameters", func() {
+		params := New().AgentsParameters()
+		s.Equal(ptr(DefaultAgentsMaxDepth), params.MaxDepth, "Expected MaxDepth to be the default")
+		s.Equal(ptr(DefaultAgentsMaxSteps), params.MaxSteps, "Expected MaxSteps to be the default")
+		s.Equal(ptr(false), params.AutoEnableToolsets, "Expected AutoEnableToolsets to be disabled by default")
+		s.Equal(ptr(true), params.RequireApproval, "Expected RequireApproval to be enabled by default")
+		s.Equal(ptr(false), params.ParallelToolCalls, "Expected ParallelToolCalls to be disabled by default")
+	})
+	s.Run("policies limit max-depth", func() {
+		s.baseConfig.agentsConfig.MaxDepth = ptr(3)
+		s.baseConfig.Enforce(test.Must(policies.ReadToml(`
+[agents]
+max-depth = 2
+`)))
+		s.Equal(ptr(2), s.baseConfig.AgentsParameters().MaxDepth, "Expected MaxDepth to be limited by policies")
+	})
+	s.Run("policies preserve stricter max-depth", func() {
+		s.baseConfig.agentsConfig.MaxDepth = ptr(0)
+		s.baseConfig.Enforce(test.Must(policies.ReadToml(`
+[agents]
+max-depth = 2
+`)))
+		s.Equal(ptr(0), s.baseConfig.AgentsParameters().MaxDepth, "Expected MaxDepth to remain as configured")
+	})
+}
+
 func TestConfigEnforce(t *testing.T) {
 	suite.Run(t, new(ConfigEnforceTestSuite))
 }
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigInferenceParametersTestSuite struct {
+	suite.Suite
+}
+
+func (s *ConfigInferenceParametersTestSuite) TestWithDefaultParameters() {
+	result := New().InferenceParameters("ollama")
+	s.Equal(ptr(true), result.Enabled, "Expected Enabled to be true by default")
+	s.Nil(result.Vision, "Expected Vision to be detected by default")
+}
+
+func (s *ConfigInferenceParametersTestSuite) TestVision() {
+	cfg := New()
+	cfg.InferenceConfig.Vision = ptr(true)
+	cfg.InferenceConfig.Provider["gemini"] = api.InferenceParameters{Vision: ptr(false)}
+	s.Run("Global vision is applied", func() {
+		s.Equal(ptr(true), cfg.InferenceParameters("ollama").Vision)
+	})
+	s.Run("Provider vision overrides global", func() {
+		s.Equal(ptr(false), cfg.InferenceParameters("gemini").Vision)
+	})
+}
+
+func TestConfigInferenceParameters(t *testing.T) {
+	suite.Run(t, new(ConfigInferenceParametersTestSuite))
+}
@@ -80,6 +80,169 @@ func (s *ConfigToolsParametersTestSuite) TestWithToolsConfig() {
 	})
 }
 
+func (s *ConfigToolsParametersTestSuite) TestAllowedAndDeniedTools() {
+	cfg := New()
+	cfg.toolsConfig.AllowedTools = []string{"*_list"}
+	cfg.toolsConfig.DeniedTools = []string{"*_exec"}
+	cfg.toolsConfig.Provider["kubernetes"] = api.ToolsParameters{
+		AllowedTools: []string{"pods_*"},
+		DeniedTools:  []string{"pods_delete", "*_exec"},
+	}
+	s.Run("Global parameters apply to providers without specific parameters", func() {
+		result := cfg.ToolsParameters("github")
+		s.Equal([]string{"*_list"}, result.AllowedTools)
+		s.Equal([]string{"*_exec"}, result.DeniedTools)
+	})
+	s.Run("Provider allowed tools take precedence", func() {
+		s.Equal([]string{"pods_*"}, cfg.ToolsParameters("kubernetes").AllowedTools)
+	})
+	s.Run("Denied tools are accumulated without duplicates", func() {
+		s.Equal([]string{"*_exec", "pods_delete"}, cfg.ToolsParameters("kubernetes").DeniedTools)
+	})
+}
+
+func (s *ConfigToolsParametersTestSuite) TestAllowedAndDeniedCommands() {
+	cfg := New()
+	cfg.toolsConfig.AllowedCommands = []string{"ls"}
+	cfg.toolsConfig.DeniedCommands = []string{"rm *"}
+	cfg.toolsConfig.Provider["shell"] = api.ToolsParameters{
+		AllowedCommands: []string{"make *"},
+		DeniedCommands:  []string{"make deploy*", "rm *"},
+	}
+	s.Run("Global parameters apply to providers without specific parameters", func() {
+		result := cfg.ToolsParameters("other")
+		s.Equal([]string{"ls"}, result.AllowedCommands)
+		s.Equal([]string{"rm *"}, result.DeniedCommands)
+	})
+	s.Run("Provider allowed commands take precedence", func() {
+		s.Equal([]string{"make *"}, cfg.ToolsParameters("shell").AllowedCommands)
+	})
+	s.Run("Denied commands are accumulated without duplicates", func() {
+		s.Equal([]string{"rm *", "make deploy*"}, cfg.ToolsParameters("shell").DeniedCommands)
+	})
+}
+
+func (s *ConfigToolsParametersTestSuite) TestAllowedAndDeniedDomains() {
+	cfg := New()
+	cfg.toolsConfig.AllowedDomains = []string{"*.example.com"}
+	cfg.toolsConfig.DeniedDomains = []string{"internal.example.com"}
+	cfg.toolsConfig.Provider["http"] = api.ToolsParameters{
+		AllowedDomains: []string{"docs.example.com"},
+		DeniedDomains:  []string{"localhost", "internal.example.com"},
+	}
+	s.Run("Global parameters apply to providers without specific parameters", func() {
+		result := cfg.ToolsParameters("other")
+		s.Equal([]string{"*.example.com"}, result.AllowedDomains)
+		s.Equal([]string{"internal.example.com"}, result.DeniedDomains)
+	})
+	s.Run("Provider allowed domains take precedence", func() {
+		s.Equal([]string{"docs.example.com"}, cfg.ToolsParameters("http").AllowedDomains)
+	})
+	s.Run("Denied domains are accumulated without duplicates", func() {
+		s.Equal([]string{"internal.example.com", "localhost"}, cfg.ToolsParameters("http").DeniedDomains)
+	})
+}
+
+func (s *ConfigToolsParametersTestSuite) TestProxy() {
+	cfg := New()
+	s.Run("No proxy by default", func() {
+		s.Nil(cfg.ToolsParameters("http").Proxy)
+	})
+	cfg.toolsConfig.Proxy = ptr("http://proxy:3128")
+	cfg.toolsConfig.Provider["http"] = api.ToolsParameters{Proxy: ptr("http://http-proxy:8080")}
+	s.Run("Global proxy applies to providers without specific proxy", func() {
+		s.Equal(ptr("http://proxy:3128"), cfg.ToolsParameters("other").Proxy)
+	})
+	s.Run("Provider proxy takes precedence", func() {
+		s.Equal(ptr("http://http-proxy:8080"), cfg.ToolsParameters("http").Proxy)
+	})
+}
+
+func (s *ConfigToolsParametersTestSuite) TestAllowedRoots() {
+	cfg := New()
+	cfg.toolsConfig.AllowedRoots = []string{"/projects"}
+	cfg.toolsConfig.Provider["fs"] = api.ToolsParameters{AllowedRoots: []string{"/home/user"}}
+	s.Run("Global allowed roots apply to providers without specific allowed roots", func() {
+		s.Equal([]string{"/projects"}, cfg.ToolsParameters("shell").AllowedRoots)
+	})
+	s.Run("Provider allowed roots replace the global ones", func() {
+		s.Equal([]string{"/home/user"}, cfg.ToolsParameters("fs").AllowedRoots)
+	})
+}
+
+func (s *ConfigToolsParametersTestSuite) TestDatabases() {
+	cfg := New()
+	s.Run("No databases by default", func() {
+		s.Empty(cfg.ToolsParameters("sqlite").Databases)
+	})
+	cfg.toolsConfig.Provider["sqlite"] = api.ToolsParameters{Databases: []string{"app.db", "~/fixtures/test.db"}}
+	s.Run("Provider databases", func() {
+		s.Equal([]string{"app.db", "~/fixtures/test.db"}, cfg.ToolsParameters("sqlite").Databases)
+		s.Empty(cfg.ToolsParameters("other").Databases)
+	})
+}
+
+func (s *ConfigToolsParametersTestSuite) TestInstanceUrl() {
+	cfg := New()
+	s.Run("No instance URL by default", func() {
+		s.Nil(cfg.ToolsParameters("gitlab").InstanceUrl)
+	})
+	cfg.toolsConfig.Provider["gitlab"] = api.ToolsParameters{InstanceUrl: ptr("https://gitlab.example.com")}
+	s.Run("Provider instance URL", func() {
+		s.Equal(ptr("https://gitlab.example.com"), cfg.ToolsParameters("gitlab").InstanceUrl)
+		s.Nil(cfg.ToolsParameters("github").InstanceUrl)
+	})
+}
+
+func (s *ConfigToolsParametersTestSuite) TestToolsets() {
+	cfg := New()
+	s.Run("No toolsets by default", func() {
+		s.Empty(cfg.ToolsParameters("github").Toolsets)
+	})
+	cfg.toolsConfig.Provider["github"] = api.ToolsParameters{Toolsets: []string{"repos", "issues"}}
+	s.Run("Provider toolsets", func() {
+		s.Equal([]string{"repos", "issues"}, cfg.ToolsParameters("github").Toolsets)
+	})
+}
+
+func (s *ConfigToolsParametersTestSuite) TestMaxConcurrency() {
+	cfg := New()
+	cfg.toolsConfig.Provider["github"] = api.ToolsParameters{MaxConcurrency: ptr(1)}
+	s.Run("Unlimited by default", func() {
+		s.Nil(cfg.ToolsParameters("kubernetes").MaxConcurrency)
+	})
+	s.Run("Provider max concurrency takes precedence", func() {
+		cfg.toolsConfig.MaxConcurrency = ptr(4)
+		s.Equal(ptr(4), cfg.ToolsParameters("kubernetes").MaxConcurrency)
+		s.Equal(ptr(1), cfg.ToolsParameters("github").MaxConcurrency)
+	})
+}
+
+func (s *ConfigToolsParametersTestSuite) TestTimeoutsAndMaxOutputSize() {
+	cfg := New()
+	cfg.toolsConfig.ToolTimeouts = map[string]int{"*_list": 10, "*_exec": 60}
+	cfg.toolsConfig.Provider["kubernetes"] = api.ToolsParameters{
+		Timeout:       ptr(30),
+		ToolTimeouts:  map[string]int{"*_exec": 600},
+		MaxOutputSize: ptr(1024),
+	}
+	s.Run("Defaults", func() {
+		result := cfg.ToolsParameters("github")
+		s.Equal(ptr(DefaultToolsTimeout), result.Timeout)
+		s.Equal(ptr(DefaultToolsMaxOutputSize), result.MaxOutputSize)
+		s.Equal(map[string]int{"*_list": 10, "*_exec": 60}, result.ToolTimeouts)
+	})
+	s.Run("Provider parameters take precedence", func() {
+		result := cfg.ToolsParameters("kubernetes")
+		s.Equal(ptr(30), result.Timeout)
+		s.Equal(ptr(1024), result.MaxOutputSize)
+		s.Equal(map[string]int{"*_list": 10, "*_exec": 600}, result.ToolTimeouts)
+	})
+	s.Run("Global tool timeouts are not modified", func() {
+		s.Equal(map[string]int{"*_list": 10, "*_exec": 60}, cfg.toolsConfig.ToolTimeouts)
+	})
+}
+
 func TestConfigToolsParameters(t *testing.T) {
 	suite.Run(t, new(ConfigToolsParametersTestSuite))
 }
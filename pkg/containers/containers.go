@@ -2,19 +2,34 @@ package containers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
 )
 
 type Container struct {
-	ID   string
-	Name string
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Image  string `json:"image,omitempty"`
+	State  string `json:"state,omitempty"`
+	Status string `json:"status,omitempty"`
+	Ports  string `json:"ports,omitempty"`
 }
 
-const (
-	command = "podman"
-)
+type ContainerStats struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	CPUPercent  string `json:"cpu_percent"`
+	MemoryUsage string `json:"memory_usage"`
+	MemoryPerc  string `json:"memory_percent"`
+	NetworkIO   string `json:"network_io"`
+	BlockIO     string `json:"block_io"`
+	PIDs        string `json:"pids"`
+}
+
+// supportedRuntimes are the supported container runtimes (with a docker compatible CLI) in order of preference
+var supportedRuntimes = []string{"podman", "docker"}
 
 type PortMapping struct {
 	HostPort string
@@ -24,10 +39,22 @@ type PortMapping struct {
 type ListContainersFilters struct {
 	// container runs one of these images (excluding tags)
 	Images []string
+	// All includes the stopped containers
+	All bool
+}
+
+type LogsOptions struct {
+	// Tail is the number of lines to show from the end of the logs (all lines if 0)
+	Tail int
+	// Since shows the logs since this timestamp (e.g. 2024-01-31T10:00:00) or relative duration (e.g. 10m)
+	Since string
+	// Timestamps prefixes each line with its timestamp
+	Timestamps bool
 }
 
 type commandExecutor interface {
 	Output() ([]byte, error)
+	CombinedOutput() ([]byte, error)
 	// Other methods of the exec.Cmd struct could be added
 }
 
@@ -35,43 +62,176 @@ var shellCommandFunc = func(name string, arg ...string) commandExecutor {
 	return exec.Command(name, arg...)
 }
 
+var lookPathFunc = exec.LookPath
+
 type CreateContainerOptions struct {
 	Image           string
 	Env             map[string]string
 	TcpPortBindings map[int]int
+	// Command overrides the default command of the image
+	Command []string
+}
+
+// Runtime returns the first supported container runtime available in the PATH
+func Runtime() (string, error) {
+	for _, runtime := range supportedRuntimes {
+		if _, err := lookPathFunc(runtime); err == nil {
+			return runtime, nil
+		}
+	}
+	return "", fmt.Errorf("no container runtime found (%s)", strings.Join(supportedRuntimes, ", "))
+}
+
+// runtimeCommand returns the command of the available container runtime (podman if none is available)
+func runtimeCommand() string {
+	if runtime, err := Runtime(); err == nil {
+		return runtime
+	}
+	return supportedRuntimes[0]
+}
+
+// commandError wraps the error of a container runtime command including its standard error (if available)
+func commandError(message string, err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%s: %w: %s", message, err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return fmt.Errorf("%s: %w", message, err)
 }
 
 func ListContainers(filters ListContainersFilters) ([]Container, error) {
-	args := []string{"container", "list", "--format", "{{.ID}} {{.Names}}"}
+	args := []string{"container", "list", "--format", "{{.ID}}\t{{.Names}}\t{{.Image}}\t{{.State}}\t{{.Status}}\t{{.Ports}}"}
+	if filters.All {
+		args = append(args, "--all")
+	}
 	if len(filters.Images) > 0 {
 		for _, image := range filters.Images {
 			args = append(args, "--filter", fmt.Sprintf("ancestor=%s", image))
 		}
 	}
-	cmd := shellCommandFunc(command, args...)
+	cmd := shellCommandFunc(runtimeCommand(), args...)
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("failed to list containers: %w", err)
+		return nil, commandError("failed to list containers", err)
 	}
-	var containers []Container
+	containers := make([]Container, 0)
 	for info := range strings.SplitSeq(string(output), "\n") {
 		if info == "" {
 			continue
 		}
-		parts := strings.Split(info, " ")
-		if len(parts) != 2 {
+		parts := strings.Split(info, "\t")
+		if len(parts) != 6 {
 			continue
 		}
-		id := parts[0]
-		name := parts[1]
-		containers = append(containers, Container{ID: id, Name: name})
+		containers = append(containers, Container{
+			ID:     parts[0],
+			Name:   parts[1],
+			Image:  parts[2],
+			State:  parts[3],
+			Status: parts[4],
+			Ports:  parts[5],
+		})
 	}
 	return containers, nil
 }
 
+// InspectContainer returns the low-level information of the container in JSON format
+func InspectContainer(id string) (string, error) {
+	cmd := shellCommandFunc(runtimeCommand(), "container", "inspect", id)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", commandError("failed to inspect container", err)
+	}
+	return string(output), nil
+}
+
+// ContainerLogs returns the logs of the container (both its standard output and error)
+func ContainerLogs(id string, options LogsOptions) (string, error) {
+	args := []string{"container", "logs"}
+	if options.Tail > 0 {
+		args = append(args, "--tail", fmt.Sprintf("%d", options.Tail))
+	}
+	if options.Since != "" {
+		args = append(args, "--since", options.Since)
+	}
+	if options.Timestamps {
+		args = append(args, "--timestamps")
+	}
+	cmd := shellCommandFunc(runtimeCommand(), append(args, id)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get container logs: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
+
+// ListContainerStats returns a snapshot of the resource usage of the provided containers (all running containers if
+// none is provided)
+func ListContainerStats(ids ...string) ([]ContainerStats, error) {
+	args := []string{"container", "stats", "--no-stream", "--format",
+		"{{.ID}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDs}}"}
+	cmd := shellCommandFunc(runtimeCommand(), append(args, ids...)...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, commandError("failed to get container stats", err)
+	}
+	stats := make([]ContainerStats, 0)
+	for info := range strings.SplitSeq(string(output), "\n") {
+		parts := strings.Split(info, "\t")
+		if len(parts) != 8 {
+			continue
+		}
+		stats = append(stats, ContainerStats{
+			ID:          parts[0],
+			Name:        parts[1],
+			CPUPercent:  parts[2],
+			MemoryUsage: parts[3],
+			MemoryPerc:  parts[4],
+			NetworkIO:   parts[5],
+			BlockIO:     parts[6],
+			PIDs:        parts[7],
+		})
+	}
+	return stats, nil
+}
+
+func StartContainer(id string) error {
+	cmd := shellCommandFunc(runtimeCommand(), "container", "start", id)
+	if _, err := cmd.Output(); err != nil {
+		return commandError("failed to start container", err)
+	}
+	return nil
+}
+
+// StopContainer stops the container, it's killed if it doesn't stop within the timeout (runtime default if nil)
+func StopContainer(id string, timeout *int) error {
+	args := []string{"container", "stop"}
+	if timeout != nil {
+		args = append(args, "--time", fmt.Sprintf("%d", *timeout))
+	}
+	cmd := shellCommandFunc(runtimeCommand(), append(args, id)...)
+	if _, err := cmd.Output(); err != nil {
+		return commandError("failed to stop container", err)
+	}
+	return nil
+}
+
+// RemoveContainer removes the container, running containers are removed only if force is set
+func RemoveContainer(id string, force bool) error {
+	args := []string{"container", "rm"}
+	if force {
+		args = append(args, "--force")
+	}
+	cmd := shellCommandFunc(runtimeCommand(), append(args, id)...)
+	if _, err := cmd.Output(); err != nil {
+		return commandError("failed to remove container", err)
+	}
+	return nil
+}
+
 func GetContainerEnvironmentVariables(id string, prefix *string) (map[string]string, error) {
 	args := []string{"container", "inspect", id, "--format", "{{range .Config.Env}}{{.}}\n{{end}}"}
-	cmd := shellCommandFunc(command, args...)
+	cmd := shellCommandFunc(runtimeCommand(), args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get container environment variables: %w", err)
@@ -92,7 +252,7 @@ func GetContainerEnvironmentVariables(id string, prefix *string) (map[string]str
 
 func GetContainerPortMapping(id string, port string, protocol string) (string, error) {
 	args := []string{"container", "inspect", id, "--format", "{{json .NetworkSettings.Ports}}"}
-	cmd := shellCommandFunc(command, args...)
+	cmd := shellCommandFunc(runtimeCommand(), args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get container port mapping: %w", err)
@@ -121,7 +281,8 @@ func CreateContainer(options CreateContainerOptions) (string, error) {
 		args = append(args, "-p", fmt.Sprintf("%d:%d", hostPort, port))
 	}
 	args = append(args, options.Image)
-	cmd := shellCommandFunc(command, args...)
+	args = append(args, options.Command...)
+	cmd := shellCommandFunc(runtimeCommand(), args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to create container: %w", err)
@@ -1,7 +1,9 @@
 package containers
 
 import (
+	"os/exec"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -13,43 +15,71 @@ func (m *MockCommand) Output() ([]byte, error) {
 	return m.OutputBytes, nil
 }
 
+func (m *MockCommand) CombinedOutput() ([]byte, error) {
+	return m.OutputBytes, nil
+}
+
+// stubRuntimes makes only the provided container runtimes available in the PATH for the duration of the test
+func stubRuntimes(t *testing.T, runtimes ...string) {
+	origLookPathFunc := lookPathFunc
+	t.Cleanup(func() { lookPathFunc = origLookPathFunc })
+	lookPathFunc = func(file string) (string, error) {
+		if slices.Contains(runtimes, file) {
+			return "/usr/bin/" + file, nil
+		}
+		return "", exec.ErrNotFound
+	}
+}
+
 func TestListContainers(t *testing.T) {
 	tests := []struct {
-		name           string
-		filters        ListContainersFilters
-		commandResult  string
-		expectedArgs   []string
-		expectedOutput []Container
+		name            string
+		runtimes        []string
+		expectedCommand string
+		filters         ListContainersFilters
+		commandResult   string
+		expectedArgs    []string
+		expectedOutput  []Container
 	}{
 		{
-			name:           "no filters",
-			filters:        ListContainersFilters{},
-			commandResult:  "1234567890 container-1234567890\n1234567891 container-1234567891\n",
-			expectedArgs:   []string{"container", "list", "--format", "{{.ID}} {{.Names}}"},
-			expectedOutput: []Container{{ID: "1234567890", Name: "container-1234567890"}, {ID: "1234567891", Name: "container-1234567891"}},
+			name:            "no filters",
+			runtimes:        []string{"docker", "podman"},
+			expectedCommand: "podman",
+			filters:         ListContainersFilters{},
+			commandResult:   "1234567890\tcontainer-1234567890\tpostgres:17\trunning\tUp 2 hours\t0.0.0.0:5432->5432/tcp\n1234567891\tcontainer-1234567891\tredis:8\trunning\tUp 1 hour\t\n",
+			expectedArgs:    []string{"container", "list", "--format", "{{.ID}}\t{{.Names}}\t{{.Image}}\t{{.State}}\t{{.Status}}\t{{.Ports}}"},
+			expectedOutput: []Container{
+				{ID: "1234567890", Name: "container-1234567890", Image: "postgres:17", State: "running", Status: "Up 2 hours", Ports: "0.0.0.0:5432->5432/tcp"},
+				{ID: "1234567891", Name: "container-1234567891", Image: "redis:8", State: "running", Status: "Up 1 hour"},
+			},
 		},
 		{
-			name: "single image filter",
+			name:            "single image filter",
+			runtimes:        []string{"docker"},
+			expectedCommand: "docker",
 			filters: ListContainersFilters{
 				Images: []string{"postgres"},
 			},
 			commandResult:  "\n",
-			expectedArgs:   []string{"container", "list", "--format", "{{.ID}} {{.Names}}", "--filter", "ancestor=postgres"},
+			expectedArgs:   []string{"container", "list", "--format", "{{.ID}}\t{{.Names}}\t{{.Image}}\t{{.State}}\t{{.Status}}\t{{.Ports}}", "--filter", "ancestor=postgres"},
 			expectedOutput: []Container{},
 		},
 		{
-			name: "multiple image filters",
+			name:            "multiple image filters",
+			runtimes:        []string{},
+			expectedCommand: "podman",
 			filters: ListContainersFilters{
 				Images: []string{"postgres", "redis"},
 			},
-			commandResult:  "1234567891 container-1234567891\n",
-			expectedArgs:   []string{"container", "list", "--format", "{{.ID}} {{.Names}}", "--filter", "ancestor=postgres", "--filter", "ancestor=redis"},
-			expectedOutput: []Container{{ID: "1234567891", Name: "container-1234567891"}},
+			commandResult:  "1234567891\tcontainer-1234567891\tredis:8\texited\tExited (0) 1 hour ago\t\n",
+			expectedArgs:   []string{"container", "list", "--format", "{{.ID}}\t{{.Names}}\t{{.Image}}\t{{.State}}\t{{.Status}}\t{{.Ports}}", "--filter", "ancestor=postgres", "--filter", "ancestor=redis"},
+			expectedOutput: []Container{{ID: "1234567891", Name: "container-1234567891", Image: "redis:8", State: "exited", Status: "Exited (0) 1 hour ago"}},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			stubRuntimes(t, tt.runtimes...)
 
 			origShellCommandFunc := shellCommandFunc
 			defer func() { shellCommandFunc = origShellCommandFunc }()
@@ -69,8 +99,8 @@ func TestListContainers(t *testing.T) {
 			}
 
 			// Verify the command name
-			if capturedCommand != command {
-				t.Errorf("Expected command %q, got %q", command, capturedCommand)
+			if capturedCommand != tt.expectedCommand {
+				t.Errorf("Expected command %q, got %q", tt.expectedCommand, capturedCommand)
 			}
 
 			// Verify the arguments
@@ -120,6 +150,7 @@ func TestGetContainerEnvironmentVariables(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			stubRuntimes(t, "podman")
 			origShellCommandFunc := shellCommandFunc
 			defer func() { shellCommandFunc = origShellCommandFunc }()
 
@@ -136,8 +167,8 @@ func TestGetContainerEnvironmentVariables(t *testing.T) {
 			if err != nil {
 				t.Fatalf("GetContainerEnvironmentVariables() error = %v", err)
 			}
-			if capturedCommand != command {
-				t.Errorf("Expected command %q, got %q", command, capturedCommand)
+			if capturedCommand != "podman" {
+				t.Errorf("Expected command %q, got %q", "podman", capturedCommand)
 			}
 			if !reflect.DeepEqual(capturedArgs, tt.expectedArgs) {
 				t.Errorf("Expected args %v, got %v", tt.expectedArgs, capturedArgs)
@@ -182,6 +213,7 @@ func TestGetContainerPortMapping(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			stubRuntimes(t, "podman")
 			origShellCommandFunc := shellCommandFunc
 			defer func() { shellCommandFunc = origShellCommandFunc }()
 
@@ -194,8 +226,8 @@ func TestGetContainerPortMapping(t *testing.T) {
 			}
 
 			output, err := GetContainerPortMapping(tt.id, tt.port, tt.protocol)
-			if capturedCommand != command {
-				t.Errorf("Expected command %q, got %q", command, capturedCommand)
+			if capturedCommand != "podman" {
+				t.Errorf("Expected command %q, got %q", "podman", capturedCommand)
 			}
 			if !reflect.DeepEqual(capturedArgs, tt.expectedArgs) {
 				t.Errorf("Expected args %v, got %v", tt.expectedArgs, capturedArgs)
@@ -212,3 +244,196 @@ func TestGetContainerPortMapping(t *testing.T) {
 		})
 	}
 }
+
+func TestRuntime(t *testing.T) {
+	tests := []struct {
+		name            string
+		available       []string
+		expectedRuntime string
+		expectedError   string
+	}{
+		{
+			name:            "podman preferred",
+			available:       []string{"docker", "podman"},
+			expectedRuntime: "podman",
+		},
+		{
+			name:            "docker fallback",
+			available:       []string{"docker"},
+			expectedRuntime: "docker",
+		},
+		{
+			name:          "no runtime",
+			available:     []string{},
+			expectedError: "no container runtime found (podman, docker)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubRuntimes(t, tt.available...)
+
+			runtime, err := Runtime()
+			if err != nil {
+				if err.Error() != tt.expectedError {
+					t.Errorf("Expected error %v, got %v", tt.expectedError, err.Error())
+				}
+			} else if runtime != tt.expectedRuntime {
+				t.Errorf("Expected runtime %q, got %q", tt.expectedRuntime, runtime)
+			}
+		})
+	}
+}
+
+func TestContainerLogs(t *testing.T) {
+	tests := []struct {
+		name         string
+		options      LogsOptions
+		expectedArgs []string
+	}{
+		{
+			name:         "no options",
+			options:      LogsOptions{},
+			expectedArgs: []string{"container", "logs", "1234567890"},
+		},
+		{
+			name:         "tail and since",
+			options:      LogsOptions{Tail: 100, Since: "10m"},
+			expectedArgs: []string{"container", "logs", "--tail", "100", "--since", "10m", "1234567890"},
+		},
+		{
+			name:         "timestamps",
+			options:      LogsOptions{Timestamps: true},
+			expectedArgs: []string{"container", "logs", "--timestamps", "1234567890"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origShellCommandFunc := shellCommandFunc
+			defer func() { shellCommandFunc = origShellCommandFunc }()
+
+			var capturedArgs []string
+			shellCommandFunc = func(name string, args ...string) commandExecutor {
+				capturedArgs = args
+				return &MockCommand{OutputBytes: []byte("started\nlistening on 5432\n")}
+			}
+
+			output, err := ContainerLogs("1234567890", tt.options)
+			if err != nil {
+				t.Fatalf("ContainerLogs() error = %v", err)
+			}
+			if !reflect.DeepEqual(capturedArgs, tt.expectedArgs) {
+				t.Errorf("Expected args %v, got %v", tt.expectedArgs, capturedArgs)
+			}
+			if output != "started\nlistening on 5432\n" {
+				t.Errorf("Unexpected output %q", output)
+			}
+		})
+	}
+}
+
+func TestListContainerStats(t *testing.T) {
+	origShellCommandFunc := shellCommandFunc
+	defer func() { shellCommandFunc = origShellCommandFunc }()
+
+	var capturedArgs []string
+	shellCommandFunc = func(name string, args ...string) commandExecutor {
+		capturedArgs = args
+		return &MockCommand{OutputBytes: []byte("1234567890\tcontainer-1234567890\t1.50%\t25MB / 2GB\t1.22%\t1kB / 2kB\t0B / 4MB\t7\n")}
+	}
+
+	output, err := ListContainerStats("1234567890")
+	if err != nil {
+		t.Fatalf("ListContainerStats() error = %v", err)
+	}
+	expectedArgs := []string{"container", "stats", "--no-stream", "--format",
+		"{{.ID}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDs}}", "1234567890"}
+	if !reflect.DeepEqual(capturedArgs, expectedArgs) {
+		t.Errorf("Expected args %v, got %v", expectedArgs, capturedArgs)
+	}
+	expectedOutput := []ContainerStats{{ID: "1234567890", Name: "container-1234567890", CPUPercent: "1.50%",
+		MemoryUsage: "25MB / 2GB", MemoryPerc: "1.22%", NetworkIO: "1kB / 2kB", BlockIO: "0B / 4MB", PIDs: "7"}}
+	if !reflect.DeepEqual(output, expectedOutput) {
+		t.Errorf("Expected output %v, got %v", expectedOutput, output)
+	}
+}
+
+func TestContainerLifecycle(t *testing.T) {
+	timeout := 5
+	tests := []struct {
+		name         string
+		call         func() error
+		expectedArgs []string
+	}{
+		{
+			name:         "start",
+			call:         func() error { return StartContainer("1234567890") },
+			expectedArgs: []string{"container", "start", "1234567890"},
+		},
+		{
+			name:         "stop",
+			call:         func() error { return StopContainer("1234567890", nil) },
+			expectedArgs: []string{"container", "stop", "1234567890"},
+		},
+		{
+			name:         "stop with timeout",
+			call:         func() error { return StopContainer("1234567890", &timeout) },
+			expectedArgs: []string{"container", "stop", "--time", "5", "1234567890"},
+		},
+		{
+			name:         "remove",
+			call:         func() error { return RemoveContainer("1234567890", false) },
+			expectedArgs: []string{"container", "rm", "1234567890"},
+		},
+		{
+			name:         "force remove",
+			call:         func() error { return RemoveContainer("1234567890", true) },
+			expectedArgs: []string{"container", "rm", "--force", "1234567890"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origShellCommandFunc := shellCommandFunc
+			defer func() { shellCommandFunc = origShellCommandFunc }()
+
+			var capturedArgs []string
+			shellCommandFunc = func(name string, args ...string) commandExecutor {
+				capturedArgs = args
+				return &MockCommand{OutputBytes: []byte("1234567890\n")}
+			}
+
+			if err := tt.call(); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if !reflect.DeepEqual(capturedArgs, tt.expectedArgs) {
+				t.Errorf("Expected args %v, got %v", tt.expectedArgs, capturedArgs)
+			}
+		})
+	}
+}
+
+func TestCreateContainer(t *testing.T) {
+	origShellCommandFunc := shellCommandFunc
+	defer func() { shellCommandFunc = origShellCommandFunc }()
+
+	var capturedArgs []string
+	shellCommandFunc = func(name string, args ...string) commandExecutor {
+		capturedArgs = args
+		return &MockCommand{OutputBytes: []byte("1234567890\n")}
+	}
+
+	_, err := CreateContainer(CreateContainerOptions{
+		Image:           "redis",
+		TcpPortBindings: map[int]int{6379: 16379},
+		Command:         []string{"redis-server", "--requirepass", "secret"},
+	})
+	if err != nil {
+		t.Fatalf("CreateContainer() error = %v", err)
+	}
+	expectedArgs := []string{"container", "run", "-d", "--rm", "-p", "16379:6379", "redis", "redis-server", "--requirepass", "secret"}
+	if !reflect.DeepEqual(capturedArgs, expectedArgs) {
+		t.Errorf("Expected args %v, got %v", expectedArgs, capturedArgs)
+	}
+}
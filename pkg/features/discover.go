@@ -23,6 +23,15 @@ type Features struct {
 	ToolsNotAvailable          []api.ToolsProvider     `json:"toolsNotAvailable"` // List of not available tools
 	// TODO: should this be exposed in the outputs?
 	ToolsDisabledByPolicy []api.ToolsProvider `json:"-"` // List of tools providers disabled
+	// FilteredTools is the list of tools of the available tools providers that are filtered out by the configuration and policies
+	// (only populated on demand since the MCP servers must be started to list their tools)
+	FilteredTools []api.FilteredTool `json:"filteredTools,omitempty"`
+	// DuplicateTools is the list of tool names exposed by more than one of the available tools providers
+	// (only populated on demand, like FilteredTools)
+	DuplicateTools []api.DuplicateTool `json:"duplicateTools,omitempty"`
+	// EmptyToolsets is the list of available tools providers with all of their tools filtered out by the configuration and policies
+	// (only populated on demand, like FilteredTools)
+	EmptyToolsets []api.EmptyToolset `json:"emptyToolsets,omitempty"`
 }
 
 // ToJSON converts the features to a generic JSON string representation.
@@ -53,6 +62,24 @@ func (f *Features) ToHumanReadable() string {
 	for _, provider := range f.ToolsNotAvailable {
 		_, _ = fmt.Fprint(ret, toHumanReadable(provider))
 	}
+	if len(f.FilteredTools) > 0 {
+		_, _ = fmt.Fprint(ret, "Filtered Tools:\n")
+		for _, filteredTool := range f.FilteredTools {
+			_, _ = fmt.Fprintf(ret, "  - %s (%s)\n", filteredTool.Name, filteredTool.Toolset)
+			_, _ = fmt.Fprintf(ret, "    Reason: %s\n", filteredTool.Reason)
+		}
+	}
+	for _, emptyToolset := range f.EmptyToolsets {
+		_, _ = fmt.Fprintf(ret, "Warning: toolset '%s' provides no tools, %s\n", emptyToolset.Toolset, emptyToolset.Reason)
+	}
+	for _, duplicateTool := range f.DuplicateTools {
+		prefixedNames := make([]string, 0, len(duplicateTool.Toolsets))
+		for _, toolset := range duplicateTool.Toolsets {
+			prefixedNames = append(prefixedNames, toolset+"_"+duplicateTool.Name)
+		}
+		_, _ = fmt.Fprintf(ret, "Warning: tool '%s' is exposed by multiple toolsets (%s), it is available as %s\n",
+			duplicateTool.Name, strings.Join(duplicateTool.Toolsets, ", "), strings.Join(prefixedNames, ", "))
+	}
 	return ret.String()
 }
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
 	"github.com/manusa/ai-cli/pkg/config"
 	"github.com/manusa/ai-cli/pkg/inference"
 	"github.com/manusa/ai-cli/pkg/policies"
@@ -249,6 +250,12 @@ func (s *DiscoverTestSuite) TestDiscoverToJSON() {
 	})
 }
 
+func (s *DiscoverTestSuite) TestToHumanReadableWarnsAboutDuplicateTools() {
+	features := &Features{DuplicateTools: []api.DuplicateTool{{Name: "search", Toolsets: []string{"github", "gitlab"}}}}
+	s.Contains(features.ToHumanReadable(),
+		"Warning: tool 'search' is exposed by multiple toolsets (github, gitlab), it is available as github_search, gitlab_search\n")
+}
+
 func TestDiscover(t *testing.T) {
 	suite.Run(t, new(DiscoverTestSuite))
 }
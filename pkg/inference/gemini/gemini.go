@@ -18,6 +18,8 @@ import (
 
 type Provider struct {
 	api.BasicInferenceProvider
+	// parallelToolCalls is set if the agents might execute the tool calls concurrently
+	parallelToolCalls bool
 }
 
 const (
@@ -34,6 +36,8 @@ func (p *Provider) Initialize(ctx context.Context) {
 	// TODO: probably move to features.Discover orchestration
 	if cfg := config.GetConfig(ctx); cfg != nil {
 		p.InferenceParameters = cfg.InferenceParameters(p.Attributes().Name())
+		parallelToolCalls := cfg.AgentsParameters().ParallelToolCalls
+		p.parallelToolCalls = parallelToolCalls != nil && *parallelToolCalls
 	}
 
 	p.Available = p.getApiKey() != ""
@@ -46,6 +50,11 @@ func (p *Provider) Initialize(ctx context.Context) {
 	}
 }
 
+// SupportsVision returns true unless disabled by configuration, Gemini models are natively multimodal
+func (p *Provider) SupportsVision() bool {
+	return p.Vision == nil || *p.Vision
+}
+
 func (p *Provider) GetInference(ctx context.Context) (model.ToolCallingChatModel, error) {
 	geminiCli, err := genai.NewClient(ctx, &genai.ClientConfig{
 		APIKey: p.getApiKey(),
@@ -64,6 +73,10 @@ func (p *Provider) getApiKey() string {
 }
 
 func (p *Provider) SystemPrompt() string {
+	parallelism := "Tools are executed sequentially. You may request multiple tool calls, but they will be executed one at a time in the order you provide."
+	if p.parallelToolCalls {
+		parallelism = "Tools might be executed concurrently. Only request multiple tool calls in the same step when they are independent of each other, the results are provided in the order you requested them."
+	}
 	// Adapted from https://github.com/google-gemini/gemini-cli/blob/5c2bb990d895254e6563acfd26946c389125387f/packages/core/src/core/prompts.ts#L50
 	return fmt.Sprintf(`
 You are an interactive CLI agent specializing in general tasks.
@@ -93,13 +106,13 @@ Today is %s.
 ## Tool Usage
 - **Tool Catalogue:** You are presented with a catalogue of available tools. You can enable any tool you deem useful to fulfill the user's request at any time.
 - **Tool Enabling:** Tools need to be enabled, you don't need to ask for permission to enable a tool. Enable the tool you consider most appropriate for the task and continue with the task **No Chitchat**. You can enable tools at any time.
-- **Parallelism:** Tools are executed sequentially. You may request multiple tool calls, but they will be executed one at a time in the order you provide.
+- **Parallelism:** %s
 
 ## URL handling
 - **Browsing:** You have access to a web browsing tool. Enable it when user asks to open a URL.
 - **URL Extraction:** When the user provides a URL, it might be incomplete, try to infer the complete URL (e.g. prepend the protocol 'https://').
 
-	`, time.Now().Format("January 2, 2006"))
+	`, time.Now().Format("January 2, 2006"), parallelism)
 }
 
 func (p *Provider) InstallHelp() error {
@@ -129,6 +142,7 @@ var instance = &Provider{
 			PublicAttr: true,
 		},
 	},
+	false,
 }
 
 func init() {
@@ -11,6 +11,7 @@ import (
 	"github.com/manusa/ai-cli/internal/test"
 	"github.com/manusa/ai-cli/pkg/config"
 	"github.com/manusa/ai-cli/pkg/keyring"
+	"github.com/manusa/ai-cli/pkg/utils"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -133,6 +134,28 @@ func (s *GeminiTestSuite) TestHasCustomSystemPrompt() {
 	s.Run("Contains today's date", func() {
 		s.Contains(instance.SystemPrompt(), fmt.Sprintf("Today is %s.", time.Now().Format("January 2, 2006")))
 	})
+	s.Run("Tools are executed sequentially by default", func() {
+		instance.Initialize(s.ctx)
+		s.Contains(instance.SystemPrompt(), "Tools are executed sequentially.")
+		s.NotContains(instance.SystemPrompt(), "Tools might be executed concurrently.")
+	})
+	s.Run("Tools might be executed concurrently with parallel tool calls", func() {
+		instance.parallelToolCalls = true
+		s.Contains(instance.SystemPrompt(), "Tools might be executed concurrently.")
+	})
+}
+
+func (s *GeminiTestSuite) TestSupportsVision() {
+	s.Run("supports vision by default", func() {
+		instance.Initialize(s.ctx)
+		s.True(instance.SupportsVision())
+	})
+	s.Run("vision can be disabled by configuration", func() {
+		cfg := config.New()
+		cfg.InferenceConfig.Vision = utils.Ptr(false)
+		instance.Initialize(config.WithConfig(s.T().Context(), cfg))
+		s.False(instance.SupportsVision())
+	})
 }
 
 func TestGemini(t *testing.T) {
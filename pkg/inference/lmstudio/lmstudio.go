@@ -19,10 +19,17 @@ var defaultBaseURL = "http://localhost:1234"
 
 type Provider struct {
 	api.BasicInferenceProvider
+	vision bool
 }
 
 var _ api.InferenceProvider = &Provider{}
 
+// ModelInfo is the response from the /api/v0/models/{model} endpoint
+type ModelInfo struct {
+	Id   string `json:"id"`
+	Type string `json:"type"` // llm, vlm, or embeddings
+}
+
 // ModelsList is the response from the /v1/models endpoint
 type ModelsList struct {
 	Data []struct {
@@ -79,6 +86,28 @@ func (p *Provider) Initialize(ctx context.Context) {
 	if p.Model == nil && p.ProviderModels != nil && len(p.ProviderModels) > 0 {
 		p.Model = &p.ProviderModels[0]
 	}
+	p.vision = p.Model != nil && p.isVisionModel(*p.Model)
+}
+
+// isVisionModel checks if the model is a vision language model (vlm) using the LM Studio REST API
+func (p *Provider) isVisionModel(model string) bool {
+	resp, err := http.Get(p.baseURL() + "/api/v0/models/" + model)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = resp.Body.Close() }()
+	modelInfo := ModelInfo{}
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&modelInfo) != nil {
+		return false
+	}
+	return modelInfo.Type == "vlm"
+}
+
+func (p *Provider) SupportsVision() bool {
+	if p.Vision != nil {
+		return *p.Vision
+	}
+	return p.vision
 }
 
 func (p *Provider) GetInference(ctx context.Context) (model.ToolCallingChatModel, error) {
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/utils"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -132,6 +133,44 @@ func (s *LmStudioTestSuite) TestInitializeWithCompatibleServer() {
 	})
 }
 
+func (s *LmStudioTestSuite) TestSupportsVision() {
+	s.MockServer.Handle(func(w http.ResponseWriter, req *http.Request) (handled bool) {
+		switch req.URL.Path {
+		case "/v1/models":
+			test.WriteObject(w, map[string]any{"data": []map[string]string{{"id": "vision-model"}}})
+			handled = true
+		case "/api/v0/models/vision-model":
+			test.WriteObject(w, map[string]string{"id": "vision-model", "type": "vlm"})
+			handled = true
+		}
+		return
+	})
+	defaultBaseURL = s.MockServer.URL()
+	s.Run("when the selected model is a vlm, supports vision", func() {
+		instance.Initialize(s.T().Context())
+		s.True(instance.SupportsVision())
+	})
+	s.Run("when the selected model is not a vlm, does not support vision", func() {
+		instance.Model = nil
+		s.MockServer.Close()
+		s.MockServer = test.NewMockServer()
+		s.MockServer.Handle(func(w http.ResponseWriter, req *http.Request) (handled bool) {
+			if req.URL.Path == "/v1/models" {
+				test.WriteObject(w, map[string]any{"data": []map[string]string{{"id": "text-model"}}})
+				handled = true
+			}
+			return
+		})
+		defaultBaseURL = s.MockServer.URL()
+		instance.Initialize(s.T().Context())
+		s.False(instance.SupportsVision())
+	})
+	s.Run("vision configuration overrides the model type", func() {
+		instance.Vision = utils.Ptr(true)
+		s.True(instance.SupportsVision())
+	})
+}
+
 func (s *LmStudioTestSuite) TestInheritsSystemPrompt() {
 	s.Run("Is empty", func() {
 		s.Empty(instance.SystemPrompt())
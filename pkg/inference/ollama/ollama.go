@@ -1,6 +1,7 @@
 package ollama
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -37,10 +38,16 @@ var (
 
 type Provider struct {
 	api.BasicInferenceProvider
+	vision bool
 }
 
 var _ api.InferenceProvider = &Provider{}
 
+// ModelInfo is part of the response from the /api/show endpoint
+type ModelInfo struct {
+	Capabilities []string `json:"capabilities"` // completion, tools, vision, thinking, embedding...
+}
+
 // ModelsList is the response from the /v1/models endpoint
 type ModelsList struct {
 	Data []struct {
@@ -84,6 +91,7 @@ func (p *Provider) Initialize(ctx context.Context) {
 	// Model selection
 	// User-provided model configuration
 	if p.Model != nil {
+		p.vision = p.isVisionModel(*p.Model)
 		return
 	}
 	p.ProviderModels, err = p.getModels()
@@ -102,9 +110,32 @@ func (p *Provider) Initialize(ctx context.Context) {
 	if p.Model == nil {
 		p.Model = &p.ProviderModels[0]
 	}
+	p.vision = p.isVisionModel(*p.Model)
 	p.Available = true
 }
 
+// isVisionModel checks if the model has the vision capability using the Ollama REST API
+func (p *Provider) isVisionModel(model string) bool {
+	body, _ := json.Marshal(map[string]string{"model": model})
+	resp, err := http.Post(p.baseURL()+"/api/show", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	defer func() { _ = resp.Body.Close() }()
+	modelInfo := ModelInfo{}
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&modelInfo) != nil {
+		return false
+	}
+	return slices.Contains(modelInfo.Capabilities, "vision")
+}
+
+func (p *Provider) SupportsVision() bool {
+	if p.Vision != nil {
+		return *p.Vision
+	}
+	return p.vision
+}
+
 func (p *Provider) GetInference(ctx context.Context) (model.ToolCallingChatModel, error) {
 	return ollama.NewChatModel(ctx, &ollama.ChatModelConfig{
 		BaseURL: p.baseURL(),
@@ -159,6 +190,7 @@ var instance = &Provider{
 			PublicAttr: false,
 		},
 	},
+	false,
 }
 
 func init() {
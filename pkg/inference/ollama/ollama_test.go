@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/utils"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -223,6 +224,44 @@ func (s *OllamaTestSuite) TestInitializeWithCompatibleServer() {
 	})
 }
 
+func (s *OllamaTestSuite) TestSupportsVision() {
+	s.MockServer.Handle(func(w http.ResponseWriter, req *http.Request) (handled bool) {
+		switch req.URL.Path {
+		case "/v1/models":
+			test.WriteObject(w, map[string]any{"data": []map[string]string{{"id": "vision-model"}, {"id": "text-model"}}})
+			handled = true
+		case "/api/show":
+			request := map[string]string{}
+			_ = json.NewDecoder(req.Body).Decode(&request)
+			capabilities := []string{"completion", "tools"}
+			if request["model"] == "vision-model" {
+				capabilities = append(capabilities, "vision")
+			}
+			test.WriteObject(w, map[string]any{"capabilities": capabilities})
+			handled = true
+		}
+		return
+	})
+	_ = os.Setenv("OLLAMA_HOST", s.MockServer.URL())
+	s.Run("when the selected model has the vision capability, supports vision", func() {
+		instance.Model = nil
+		instance.Initialize(s.T().Context())
+		s.Equal("vision-model", *instance.Model)
+		s.True(instance.SupportsVision())
+	})
+	s.Run("when the selected model has no vision capability, does not support vision", func() {
+		instance.Model = utils.Ptr("text-model")
+		instance.Initialize(s.T().Context())
+		s.False(instance.SupportsVision())
+	})
+	s.Run("vision configuration overrides the model capabilities", func() {
+		instance.Model = utils.Ptr("text-model")
+		instance.Initialize(s.T().Context())
+		instance.Vision = utils.Ptr(true)
+		s.True(instance.SupportsVision())
+	})
+}
+
 func (s *OllamaTestSuite) TestInheritsSystemPrompt() {
 	s.Run("Is empty", func() {
 		s.Empty(instance.SystemPrompt())
@@ -0,0 +1,83 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	ctr "github.com/manusa/ai-cli/pkg/containers"
+	"github.com/manusa/ai-cli/pkg/tools"
+)
+
+type Provider struct {
+	api.BasicToolsProvider
+}
+
+var _ api.ToolsProvider = &Provider{}
+
+func (p *Provider) Initialize(ctx context.Context) {
+	if cfg := config.GetConfig(ctx); cfg != nil {
+		p.ToolsParameters = cfg.ToolsParameters(p.Attributes().Name())
+	}
+	runtime, err := ctr.Runtime()
+	if err != nil {
+		p.IsAvailableReason = err.Error()
+		return
+	}
+	p.Available = true
+	p.IsAvailableReason = fmt.Sprintf("%s is available", runtime)
+}
+
+// GetTools returns the containers tools, the write tools are hidden for read-only toolsets and container_stop and
+// container_remove if destructive tools are disabled.
+func (p *Provider) GetTools(_ context.Context) []*api.Tool {
+	containerTools := []*api.Tool{
+		ContainerList,
+		ContainerInspect,
+		ContainerLogs,
+		ContainerStats,
+	}
+	if p.ReadOnly != nil && *p.ReadOnly {
+		return containerTools
+	}
+	containerTools = append(containerTools, ContainerStart)
+	if p.DisableDestructive != nil && *p.DisableDestructive {
+		return containerTools
+	}
+	return append(containerTools, ContainerStop, ContainerRemove)
+}
+
+// containerArg returns the container argument or an error message to provide to the model if it's invalid
+func containerArg(args map[string]interface{}) (string, string) {
+	container, _ := args["container"].(string)
+	container = strings.TrimSpace(container)
+	if container == "" {
+		return "", "The container ID or name is required."
+	}
+	if strings.HasPrefix(container, "-") {
+		return "", fmt.Sprintf("Invalid container '%s': container IDs and names can't start with '-'.", container)
+	}
+	return container, ""
+}
+
+// runtimeFailure returns the container runtime error message to provide to the model as the tool result
+func runtimeFailure(err error) string {
+	return fmt.Sprintf("The container runtime command failed: %s", err)
+}
+
+var instance = &Provider{
+	api.BasicToolsProvider{
+		BasicToolsAttributes: api.BasicToolsAttributes{
+			BasicFeatureAttributes: api.BasicFeatureAttributes{
+				FeatureName:        "containers",
+				FeatureDescription: "Provides access to the local container runtime (podman or docker), allowing inspection of the containers, their logs and resource usage, and managing their lifecycle.",
+			},
+		},
+	},
+}
+
+func init() {
+	tools.Register(instance)
+}
@@ -0,0 +1,159 @@
+package containers
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeRuntime is a podman replacement that records its arguments and returns canned output
+const fakeRuntime = `#!/bin/sh
+echo "$@" >> "${0%/*}/args"
+case "$2" in
+  list) printf '1234567890\tweb\tnginx:1\trunning\tUp 2 hours\t0.0.0.0:8080->80/tcp\n' ;;
+  inspect) echo '[{"Id":"1234567890"}]' ;;
+  logs) echo 'server started' ;;
+  stats) printf '1234567890\tweb\t1.50%%\t25MB / 2GB\t1.22%%\t1kB / 2kB\t0B / 4MB\t7\n' ;;
+  rm) echo "Error: container $3 is running" >&2; exit 2 ;;
+  *) echo "$3" ;;
+esac
+`
+
+type ContainersTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (s *ContainersTestSuite) SetupTest() {
+	if runtime.GOOS == "windows" {
+		s.T().Skip("the fake container runtime is a shell script")
+	}
+	s.dir = s.T().TempDir()
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, "podman"), []byte(fakeRuntime), 0755))
+	s.T().Setenv("PATH", s.dir)
+}
+
+func (s *ContainersTestSuite) args() string {
+	data, _ := os.ReadFile(filepath.Join(s.dir, "args"))
+	_ = os.Remove(filepath.Join(s.dir, "args"))
+	return strings.TrimSpace(string(data))
+}
+
+func (s *ContainersTestSuite) TestInitialize() {
+	s.Run("Available with a container runtime", func() {
+		p := &Provider{}
+		p.Initialize(s.T().Context())
+		s.True(p.IsAvailable())
+		s.Equal("podman is available", p.Reason())
+	})
+	s.Run("Not available without a container runtime", func() {
+		s.T().Setenv("PATH", s.T().TempDir())
+		p := &Provider{}
+		p.Initialize(s.T().Context())
+		s.False(p.IsAvailable())
+		s.Equal("no container runtime found (podman, docker)", p.Reason())
+	})
+}
+
+func (s *ContainersTestSuite) TestGetTools() {
+	toolNames := func(parameters api.ToolsParameters) []string {
+		p := &Provider{}
+		p.ToolsParameters = parameters
+		var names []string
+		for _, t := range p.GetTools(s.T().Context()) {
+			names = append(names, t.Name)
+		}
+		return names
+	}
+	s.Run("All tools by default", func() {
+		s.Equal([]string{"container_list", "container_inspect", "container_logs", "container_stats",
+			"container_start", "container_stop", "container_remove"}, toolNames(api.ToolsParameters{}))
+	})
+	s.Run("Read-only hides the write tools", func() {
+		s.Equal([]string{"container_list", "container_inspect", "container_logs", "container_stats"},
+			toolNames(api.ToolsParameters{ReadOnly: utils.Ptr(true)}))
+	})
+	s.Run("Disable destructive hides container_stop and container_remove", func() {
+		s.Equal([]string{"container_list", "container_inspect", "container_logs", "container_stats",
+			"container_start"}, toolNames(api.ToolsParameters{DisableDestructive: utils.Ptr(true)}))
+	})
+}
+
+func (s *ContainersTestSuite) TestContainerList() {
+	result, err := ContainerList.Function(map[string]interface{}{"all": true})
+	s.NoError(err)
+	s.JSONEq(`[{"id":"1234567890","name":"web","image":"nginx:1","state":"running","status":"Up 2 hours","ports":"0.0.0.0:8080->80/tcp"}]`, result)
+	s.Contains(s.args(), "container list --format")
+}
+
+func (s *ContainersTestSuite) TestContainerInspect() {
+	s.Run("Returns the container information", func() {
+		result, err := ContainerInspect.Function(map[string]interface{}{"container": "web"})
+		s.NoError(err)
+		s.Equal("[{\"Id\":\"1234567890\"}]\n", result)
+		s.Equal("container inspect web", s.args())
+	})
+	s.Run("Returns invalid containers to the model", func() {
+		result, err := ContainerInspect.Function(map[string]interface{}{"container": "--help"})
+		s.NoError(err)
+		s.Equal("Invalid container '--help': container IDs and names can't start with '-'.", result)
+		s.Empty(s.args())
+	})
+	s.Run("Returns missing containers to the model", func() {
+		result, err := ContainerInspect.Function(map[string]interface{}{})
+		s.NoError(err)
+		s.Equal("The container ID or name is required.", result)
+	})
+}
+
+func (s *ContainersTestSuite) TestContainerLogs() {
+	s.Run("Returns the last lines by default", func() {
+		result, err := ContainerLogs.Function(map[string]interface{}{"container": "web"})
+		s.NoError(err)
+		s.Equal("server started\n", result)
+		s.Equal("container logs --tail 100 web", s.args())
+	})
+	s.Run("Uses the tail and since parameters", func() {
+		_, err := ContainerLogs.Function(map[string]interface{}{"container": "web", "tail": 10.0, "since": "5m"})
+		s.NoError(err)
+		s.Equal("container logs --tail 10 --since 5m web", s.args())
+	})
+}
+
+func (s *ContainersTestSuite) TestContainerStats() {
+	result, err := ContainerStats.Function(map[string]interface{}{"container": "web"})
+	s.NoError(err)
+	s.JSONEq(`[{"id":"1234567890","name":"web","cpu_percent":"1.50%","memory_usage":"25MB / 2GB","memory_percent":"1.22%","network_io":"1kB / 2kB","block_io":"0B / 4MB","pids":"7"}]`, result)
+	s.True(strings.HasSuffix(s.args(), " web"))
+}
+
+func (s *ContainersTestSuite) TestContainerLifecycle() {
+	s.Run("Starts the container", func() {
+		result, err := ContainerStart.Function(map[string]interface{}{"container": "web"})
+		s.NoError(err)
+		s.Equal("Container web started.", result)
+		s.Equal("container start web", s.args())
+	})
+	s.Run("Stops the container", func() {
+		result, err := ContainerStop.Function(map[string]interface{}{"container": "web", "timeout": 3.0})
+		s.NoError(err)
+		s.Equal("Container web stopped.", result)
+		s.Equal("container stop --time 3 web", s.args())
+	})
+	s.Run("Returns runtime failures to the model", func() {
+		result, err := ContainerRemove.Function(map[string]interface{}{"container": "web"})
+		s.NoError(err)
+		s.Equal("The container runtime command failed: failed to remove container: exit status 2: Error: container web is running", result)
+		s.Equal("container rm web", s.args())
+	})
+}
+
+func TestContainers(t *testing.T) {
+	suite.Run(t, new(ContainersTestSuite))
+}
@@ -0,0 +1,236 @@
+package containers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	ctr "github.com/manusa/ai-cli/pkg/containers"
+	"github.com/manusa/ai-cli/pkg/utils"
+)
+
+const (
+	// defaultLogsTail is the number of lines returned by container_logs if no tail is provided
+	defaultLogsTail = 100
+	// maxLogsTail is the maximum number of lines returned by container_logs
+	maxLogsTail = 5000
+)
+
+var ContainerList = &api.Tool{
+	Name:        "container_list",
+	Description: "List the containers with their ID, name, image, state, status and published ports. By default only the running containers are listed.",
+	ReadOnly:    true,
+	Parameters: map[string]api.ToolParameter{
+		"all": {
+			Type:        api.Boolean,
+			Description: "Whether to include the stopped containers.",
+			Default:     false,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		all, _ := args["all"].(bool)
+		containers, err := ctr.ListContainers(ctr.ListContainersFilters{All: all})
+		if err != nil {
+			return runtimeFailure(err), nil
+		}
+		if len(containers) == 0 {
+			return "No containers found.", nil
+		}
+		data, err := json.Marshal(containers)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	},
+}
+
+var ContainerInspect = &api.Tool{
+	Name:        "container_inspect",
+	Description: "Return the low-level information of a container in JSON format (configuration, environment, mounts, network settings, state...).",
+	ReadOnly:    true,
+	Parameters: map[string]api.ToolParameter{
+		"container": {
+			Type:        api.String,
+			Description: "The container ID or name.",
+			Required:    true,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		container, invalid := containerArg(args)
+		if invalid != "" {
+			return invalid, nil
+		}
+		output, err := ctr.InspectContainer(container)
+		if err != nil {
+			return runtimeFailure(err), nil
+		}
+		return output, nil
+	},
+}
+
+var ContainerLogs = &api.Tool{
+	Name:        "container_logs",
+	Description: "Return the logs (standard output and error) of a container.",
+	ReadOnly:    true,
+	Parameters: map[string]api.ToolParameter{
+		"container": {
+			Type:        api.String,
+			Description: "The container ID or name.",
+			Required:    true,
+		},
+		"tail": {
+			Type:        api.Integer,
+			Description: "The number of lines to return from the end of the logs.",
+			Default:     defaultLogsTail,
+			Minimum:     utils.Ptr(1.0),
+			Maximum:     utils.Ptr(float64(maxLogsTail)),
+		},
+		"since": {
+			Type:        api.String,
+			Description: "Only return the logs since this timestamp (e.g. 2024-01-31T10:00:00) or relative duration (e.g. 10m, 1h).",
+		},
+		"timestamps": {
+			Type:        api.Boolean,
+			Description: "Whether to prefix each line with its timestamp.",
+			Default:     false,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		container, invalid := containerArg(args)
+		if invalid != "" {
+			return invalid, nil
+		}
+		options := ctr.LogsOptions{Tail: defaultLogsTail}
+		if tail, ok := args["tail"].(float64); ok && tail > 0 {
+			options.Tail = min(int(tail), maxLogsTail)
+		}
+		options.Since, _ = args["since"].(string)
+		options.Timestamps, _ = args["timestamps"].(bool)
+		output, err := ctr.ContainerLogs(container, options)
+		if err != nil {
+			return runtimeFailure(err), nil
+		}
+		if output == "" {
+			return "The container has no logs.", nil
+		}
+		return output, nil
+	},
+}
+
+var ContainerStats = &api.Tool{
+	Name:        "container_stats",
+	Description: "Return a snapshot of the resource usage (CPU, memory, network and block I/O, PIDs) of the running containers.",
+	ReadOnly:    true,
+	Parameters: map[string]api.ToolParameter{
+		"container": {
+			Type:        api.String,
+			Description: "The container ID or name. If not provided, the stats of all the running containers are returned.",
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		var ids []string
+		if c, _ := args["container"].(string); c != "" {
+			container, invalid := containerArg(args)
+			if invalid != "" {
+				return invalid, nil
+			}
+			ids = append(ids, container)
+		}
+		stats, err := ctr.ListContainerStats(ids...)
+		if err != nil {
+			return runtimeFailure(err), nil
+		}
+		if len(stats) == 0 {
+			return "No running containers found.", nil
+		}
+		data, err := json.Marshal(stats)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	},
+}
+
+var ContainerStart = &api.Tool{
+	Name:        "container_start",
+	Description: "Start a stopped container.",
+	Parameters: map[string]api.ToolParameter{
+		"container": {
+			Type:        api.String,
+			Description: "The container ID or name.",
+			Required:    true,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		container, invalid := containerArg(args)
+		if invalid != "" {
+			return invalid, nil
+		}
+		if err := ctr.StartContainer(container); err != nil {
+			return runtimeFailure(err), nil
+		}
+		return fmt.Sprintf("Container %s started.", container), nil
+	},
+}
+
+var ContainerStop = &api.Tool{
+	Name:        "container_stop",
+	Description: "Stop a running container. The container is killed if it doesn't stop within the timeout.",
+	// Killed processes might lose data, and containers started with --rm are removed once stopped
+	Destructive: true,
+	Parameters: map[string]api.ToolParameter{
+		"container": {
+			Type:        api.String,
+			Description: "The container ID or name.",
+			Required:    true,
+		},
+		"timeout": {
+			Type:        api.Integer,
+			Description: "The number of seconds to wait for the container to stop before killing it (container runtime default if not provided).",
+			Minimum:     utils.Ptr(0.0),
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		container, invalid := containerArg(args)
+		if invalid != "" {
+			return invalid, nil
+		}
+		var timeout *int
+		if t, ok := args["timeout"].(float64); ok && t >= 0 {
+			timeout = utils.Ptr(int(t))
+		}
+		if err := ctr.StopContainer(container, timeout); err != nil {
+			return runtimeFailure(err), nil
+		}
+		return fmt.Sprintf("Container %s stopped.", container), nil
+	},
+}
+
+var ContainerRemove = &api.Tool{
+	Name:        "container_remove",
+	Description: "Remove a container. Running containers are only removed if force is set.",
+	Destructive: true,
+	Parameters: map[string]api.ToolParameter{
+		"container": {
+			Type:        api.String,
+			Description: "The container ID or name.",
+			Required:    true,
+		},
+		"force": {
+			Type:        api.Boolean,
+			Description: "Whether to kill and remove the container if it's running.",
+			Default:     false,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		container, invalid := containerArg(args)
+		if invalid != "" {
+			return invalid, nil
+		}
+		force, _ := args["force"].(bool)
+		if err := ctr.RemoveContainer(container, force); err != nil {
+			return runtimeFailure(err), nil
+		}
+		return fmt.Sprintf("Container %s removed.", container), nil
+	},
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
 	"github.com/manusa/ai-cli/pkg/tools"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/spf13/afero"
 )
 
 type Provider struct {
@@ -15,27 +20,53 @@ type Provider struct {
 
 var _ api.ToolsProvider = &Provider{}
 
-func (p *Provider) Initialize(_ context.Context) {
+func (p *Provider) Initialize(ctx context.Context) {
+	if cfg := config.GetConfig(ctx); cfg != nil {
+		p.ToolsParameters = cfg.ToolsParameters(p.Attributes().Name())
+	}
 	p.Available = true
 	p.IsAvailableReason = "filesystem is accessible"
 }
 
+// GetTools returns the fs tools, the write tools are hidden for read-only toolsets or if destructive tools are
+// disabled (they all overwrite or delete existing files).
 func (p *Provider) GetTools(_ context.Context) []*api.Tool {
-	return []*api.Tool{
+	fsTools := []*api.Tool{
 		FileList,
+		FileRead,
+		FileFind,
+		FileGrep,
+	}
+	if (p.ReadOnly != nil && *p.ReadOnly) || (p.DisableDestructive != nil && *p.DisableDestructive) {
+		return fsTools
 	}
+	return append(fsTools, FileWrite, FileEdit, FileDelete)
 }
 
+const defaultMaxDepth = 3
+
 var FileList = &api.Tool{
 	Name: "file_list",
 	Description: "List files in the provided directory or the current working directory if none is provided." +
 		"Returns a JSON representation of the files, including their names and metadata.",
+	ReadOnly: true,
 	Parameters: map[string]api.ToolParameter{
 		"directory": {
 			Type:        api.String,
 			Description: "The directory to list files from. If not provided, the current working directory will be used.",
 			Required:    false,
 		},
+		"recursive": {
+			Type:        api.Boolean,
+			Description: "Whether to list the files in the subdirectories too.",
+			Default:     false,
+		},
+		"max_depth": {
+			Type:        api.Integer,
+			Description: "The maximum depth of subdirectories to list when recursive is true.",
+			Default:     defaultMaxDepth,
+			Minimum:     utils.Ptr(1.0),
+		},
 	},
 	Function: func(args map[string]interface{}) (string, error) {
 		directory := "."
@@ -43,21 +74,41 @@ var FileList = &api.Tool{
 		if ok && d != "" {
 			directory = d
 		}
-		files, err := os.ReadDir(directory)
+		directory, err := instance.resolvePath(directory)
 		if err != nil {
 			return "", err
 		}
+		recursive, _ := args["recursive"].(bool)
+		maxDepth := defaultMaxDepth
+		if m, ok := args["max_depth"].(float64); ok && m >= 1 {
+			maxDepth = int(m)
+		}
+		if !recursive {
+			maxDepth = 1
+		}
 		var fileInfos []interface{}
-		for _, file := range files {
-			fileInfo := map[string]interface{}{
-				"name": file.Name(),
-				"type": file.Type().String(),
+		err = afero.Walk(config.FileSystem, directory, func(path string, file os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if path == directory {
+				return nil
 			}
-			if fi, err := file.Info(); err == nil {
-				fileInfo["size"] = fi.Size()
-				fileInfo["mod_time"] = fi.ModTime().Format("2006-01-02 15:04:05")
+			name, _ := filepath.Rel(directory, path)
+			fileInfo := map[string]interface{}{
+				"name":     filepath.ToSlash(name),
+				"type":     file.Mode().Type().String(),
+				"size":     file.Size(),
+				"mod_time": file.ModTime().Format("2006-01-02 15:04:05"),
 			}
 			fileInfos = append(fileInfos, fileInfo)
+			if file.IsDir() && strings.Count(filepath.ToSlash(name), "/")+1 >= maxDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
 		}
 		fileNamesJSON, err := json.Marshal(fileInfos)
 		if err != nil {
@@ -72,7 +123,7 @@ var instance = &Provider{
 		BasicToolsAttributes: api.BasicToolsAttributes{
 			BasicFeatureAttributes: api.BasicFeatureAttributes{
 				FeatureName:        "fs",
-				FeatureDescription: "Provides access to the local filesystem, allowing listing of files and directories.",
+				FeatureDescription: "Provides access to the local filesystem, allowing listing, reading, searching and editing files and directories.",
 			},
 		},
 	},
@@ -0,0 +1,60 @@
+package fs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FsTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (s *FsTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.Require().NoError(os.MkdirAll(filepath.Join(s.dir, "a", "b", "c"), 0755))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, "root.txt"), []byte("root"), 0644))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, "a", "b", "c", "deep.txt"), []byte("deep"), 0644))
+	instance.AllowedRoots = []string{s.dir}
+}
+
+func (s *FsTestSuite) TearDownTest() {
+	instance.AllowedRoots = nil
+}
+
+func (s *FsTestSuite) fileNames(args map[string]interface{}) []string {
+	result, err := FileList.Function(args)
+	s.Require().NoError(err)
+	var files []map[string]interface{}
+	s.Require().NoError(json.Unmarshal([]byte(result), &files))
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, file["name"].(string))
+	}
+	return names
+}
+
+func (s *FsTestSuite) TestFileList() {
+	s.Run("Lists directory files", func() {
+		s.Equal([]string{"a", "root.txt"}, s.fileNames(map[string]interface{}{"directory": s.dir}))
+	})
+	s.Run("Lists files recursively with default max depth", func() {
+		s.Equal([]string{"a", "a/b", "a/b/c", "root.txt"}, s.fileNames(map[string]interface{}{"directory": s.dir, "recursive": true}))
+	})
+	s.Run("Lists files recursively with max depth", func() {
+		s.Equal([]string{"a", "a/b", "root.txt"}, s.fileNames(map[string]interface{}{"directory": s.dir, "recursive": true, "max_depth": float64(2)}))
+		s.Equal([]string{"a", "a/b", "a/b/c", "a/b/c/deep.txt", "root.txt"}, s.fileNames(map[string]interface{}{"directory": s.dir, "recursive": true, "max_depth": float64(4)}))
+	})
+	s.Run("Returns error for missing directory", func() {
+		_, err := FileList.Function(map[string]interface{}{"directory": filepath.Join(s.dir, "missing")})
+		s.Error(err)
+	})
+}
+
+func TestFs(t *testing.T) {
+	suite.Run(t, new(FsTestSuite))
+}
@@ -0,0 +1,320 @@
+package fs
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/utils"
+)
+
+const (
+	// maxReadFileSize is the maximum size of the files that can be read
+	maxReadFileSize = 10 * 1024 * 1024
+	// defaultReadLines is the number of lines returned by file_read if no end_line is provided
+	defaultReadLines = 500
+	// maxLineLength is the maximum length of the lines returned by file_read and file_grep (longer lines are truncated)
+	maxLineLength = 2000
+	// maxFindResults is the maximum number of files returned by file_find
+	maxFindResults = 1000
+	// maxGrepMatches is the maximum number of matches returned by file_grep
+	maxGrepMatches = 500
+	// maxContextLines is the maximum number of context lines around the file_grep matches
+	maxContextLines = 10
+)
+
+var FileRead = &api.Tool{
+	Name: "file_read",
+	Description: "Read the contents of a text file, optionally limited to a range of lines. " +
+		"Returns the lines prefixed with their line number. " +
+		fmt.Sprintf("If no end_line is provided, up to %d lines are returned.", defaultReadLines),
+	ReadOnly: true,
+	Parameters: map[string]api.ToolParameter{
+		"path": {
+			Type:        api.String,
+			Description: "The path of the file to read.",
+			Required:    true,
+		},
+		"start_line": {
+			Type:        api.Integer,
+			Description: "The first line to read (1-based).",
+			Default:     1,
+			Minimum:     utils.Ptr(1.0),
+		},
+		"end_line": {
+			Type:        api.Integer,
+			Description: "The last line to read (inclusive).",
+			Minimum:     utils.Ptr(1.0),
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		path, _ := args["path"].(string)
+		if path == "" {
+			return "", errors.New("path is required")
+		}
+		startLine := 1
+		if s, ok := args["start_line"].(float64); ok && s >= 1 {
+			startLine = int(s)
+		}
+		endLine := startLine + defaultReadLines - 1
+		if e, ok := args["end_line"].(float64); ok && e >= 1 {
+			endLine = int(e)
+		}
+		path, err := instance.resolvePath(path)
+		if err != nil {
+			return "", err
+		}
+		info, err := config.FileSystem.Stat(path)
+		if err != nil {
+			return statErrorResult(path, err), nil
+		}
+		if invalid := checkTextFile(path, info); invalid != "" {
+			return invalid, nil
+		}
+		lines, err := readLines(path)
+		if err != nil {
+			return "", err
+		}
+		if startLine > len(lines) {
+			return fmt.Sprintf("The file has %d lines.", len(lines)), nil
+		}
+		endLine = min(endLine, len(lines))
+		sb := strings.Builder{}
+		for i := startLine; i <= endLine; i++ {
+			sb.WriteString(fmt.Sprintf("%6d\t%s\n", i, truncateLine(lines[i-1])))
+		}
+		if endLine < len(lines) {
+			sb.WriteString(fmt.Sprintf("[Showing lines %d-%d of %d, read more by calling file_read with start_line %d]\n",
+				startLine, endLine, len(lines), endLine+1))
+		}
+		return sb.String(), nil
+	},
+}
+
+var FileFind = &api.Tool{
+	Name: "file_find",
+	Description: "Find the files matching a glob pattern in the provided directory (recursively) or the current working directory if none is provided. " +
+		"Patterns without a slash match the file name in any directory (e.g. *.go), patterns with a slash match the path relative to the directory (e.g. cmd/**/*.go). " +
+		"Files ignored by .gitignore and binary files are skipped. " +
+		"Returns a JSON representation of the matching file paths.",
+	ReadOnly: true,
+	Parameters: map[string]api.ToolParameter{
+		"pattern": {
+			Type:        api.String,
+			Description: "The glob pattern to match (supports *, ?, [...] and **).",
+			Required:    true,
+		},
+		"directory": {
+			Type:        api.String,
+			Description: "The directory to search in. If not provided, the current working directory will be used.",
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		pattern, _ := args["pattern"].(string)
+		if pattern == "" {
+			return "", errors.New("pattern is required")
+		}
+		directory, err := instance.resolvePath(directoryArg(args))
+		if err != nil {
+			return "", err
+		}
+		matcher := globRegexp(strings.TrimPrefix(pattern, "/"), strings.Contains(pattern, "/"))
+		result := struct {
+			Files     []string `json:"files"`
+			Truncated bool     `json:"truncated,omitempty"`
+		}{Files: make([]string, 0)}
+		err = walkFiles(directory, func(_, name string) error {
+			if !matcher.MatchString(name) {
+				return nil
+			}
+			if len(result.Files) >= maxFindResults {
+				result.Truncated = true
+				return errStopWalk
+			}
+			result.Files = append(result.Files, name)
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopWalk) {
+			return "", err
+		}
+		resultJSON, err := json.Marshal(result)
+		if err != nil {
+			return "", err
+		}
+		return string(resultJSON), nil
+	},
+}
+
+var FileGrep = &api.Tool{
+	Name: "file_grep",
+	Description: "Search for a regular expression in the files of the provided directory (recursively) or the current working directory if none is provided. " +
+		"Files ignored by .gitignore and binary files are skipped. " +
+		"Returns the matching lines in the path:line:content format, context lines use the path-line-content format and groups are separated by --.",
+	ReadOnly: true,
+	Parameters: map[string]api.ToolParameter{
+		"pattern": {
+			Type:        api.String,
+			Description: "The regular expression to search for (RE2 syntax).",
+			Required:    true,
+		},
+		"directory": {
+			Type:        api.String,
+			Description: "The directory to search in. If not provided, the current working directory will be used.",
+		},
+		"include": {
+			Type:        api.String,
+			Description: "Only search the files matching this glob pattern (e.g. *.go).",
+		},
+		"context_lines": {
+			Type:        api.Integer,
+			Description: "The number of lines to show before and after each match.",
+			Default:     0,
+			Minimum:     utils.Ptr(0.0),
+			Maximum:     utils.Ptr(float64(maxContextLines)),
+		},
+		"case_insensitive": {
+			Type:        api.Boolean,
+			Description: "Whether the search is case-insensitive.",
+			Default:     false,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		pattern, _ := args["pattern"].(string)
+		if pattern == "" {
+			return "", errors.New("pattern is required")
+		}
+		if caseInsensitive, _ := args["case_insensitive"].(bool); caseInsensitive {
+			pattern = "(?i)" + pattern
+		}
+		expression, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Sprintf("Invalid pattern: %s.", err), nil
+		}
+		directory, err := instance.resolvePath(directoryArg(args))
+		if err != nil {
+			return "", err
+		}
+		var include *regexp.Regexp
+		if i, ok := args["include"].(string); ok && i != "" {
+			include = globRegexp(strings.TrimPrefix(i, "/"), strings.Contains(i, "/"))
+		}
+		contextLines := 0
+		if c, ok := args["context_lines"].(float64); ok && c > 0 {
+			contextLines = min(int(c), maxContextLines)
+		}
+		sb := strings.Builder{}
+		matches := 0
+		err = walkFiles(directory, func(path, name string) error {
+			if include != nil && !include.MatchString(name) {
+				return nil
+			}
+			lines, err := readLines(path)
+			if err != nil {
+				return nil // Unreadable files are skipped
+			}
+			lastPrinted := -1
+			for i, line := range lines {
+				if !expression.MatchString(line) {
+					continue
+				}
+				if matches >= maxGrepMatches {
+					sb.WriteString(fmt.Sprintf("[Showing the first %d matches, narrow down the search to see more]\n", maxGrepMatches))
+					return errStopWalk
+				}
+				matches++
+				from, to := max(0, i-contextLines, lastPrinted+1), min(len(lines)-1, i+contextLines)
+				if contextLines > 0 && sb.Len() > 0 && (lastPrinted < 0 || from > lastPrinted+1) {
+					sb.WriteString("--\n")
+				}
+				for j := from; j <= to; j++ {
+					separator := "-"
+					if expression.MatchString(lines[j]) {
+						separator = ":"
+					}
+					sb.WriteString(fmt.Sprintf("%s%s%d%s%s\n", name, separator, j+1, separator, truncateLine(lines[j])))
+				}
+				lastPrinted = max(lastPrinted, to)
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopWalk) {
+			return "", err
+		}
+		if matches == 0 {
+			return "No matches found.", nil
+		}
+		return sb.String(), nil
+	},
+}
+
+// statErrorResult returns the result explaining to the model why the file can't be accessed
+func statErrorResult(path string, err error) string {
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Sprintf("File %s does not exist.", path)
+	}
+	return fmt.Sprintf("Unable to access %s: %s.", path, err)
+}
+
+// checkTextFile returns the result explaining to the model why the file can't be read as text (empty if it can)
+func checkTextFile(path string, info os.FileInfo) string {
+	switch {
+	case info.IsDir():
+		return fmt.Sprintf("%s is a directory, use file_find to list its files.", path)
+	case info.Size() > maxReadFileSize:
+		return fmt.Sprintf("%s exceeds the maximum size of %d bytes.", path, maxReadFileSize)
+	case isBinary(path):
+		return fmt.Sprintf("%s is a binary file.", path)
+	}
+	return ""
+}
+
+// errStopWalk stops walking the directory once the maximum number of results is reached
+var errStopWalk = errors.New("stop walk")
+
+func directoryArg(args map[string]interface{}) string {
+	if d, ok := args["directory"].(string); ok && d != "" {
+		return d
+	}
+	return "."
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := config.FileSystem.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	var lines []string
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			lines = append(lines, strings.TrimRight(line, "\r\n"))
+		}
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func truncateLine(line string) string {
+	if len(line) <= maxLineLength {
+		return line
+	}
+	end := maxLineLength
+	for end > 0 && !utf8.RuneStart(line[end]) {
+		end--
+	}
+	return line[:end] + "... [line truncated]"
+}
@@ -0,0 +1,144 @@
+package fs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/suite"
+)
+
+type FsReadTestSuite struct {
+	suite.Suite
+	originalFileSystem afero.Fs
+}
+
+func (s *FsReadTestSuite) SetupTest() {
+	s.originalFileSystem = config.FileSystem
+	config.FileSystem = afero.NewMemMapFs()
+	instance.AllowedRoots = []string{"/project"}
+	files := map[string]string{
+		"/project/.gitignore":            "*.log\nbuild/\n!keep.log\n",
+		"/project/main.go":               "package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n",
+		"/project/README.md":             "# Project\nHello world\n",
+		"/project/app.log":               "hello from the logs\n",
+		"/project/keep.log":              "hello kept\n",
+		"/project/build/out.go":          "package build // hello\n",
+		"/project/pkg/util/util.go":      "package util\n\n// Hello returns a greeting\nfunc Hello() string {\n\treturn \"hello\"\n}\n",
+		"/project/pkg/.gitignore":        "/util/generated.go\n",
+		"/project/pkg/util/generated.go": "package util // hello\n",
+		"/project/image.png":             "\x89PNG\x00\x00hello",
+		"/project/.git/config":           "hello\n",
+	}
+	for path, contents := range files {
+		s.Require().NoError(afero.WriteFile(config.FileSystem, path, []byte(contents), 0644))
+	}
+}
+
+func (s *FsReadTestSuite) TearDownTest() {
+	config.FileSystem = s.originalFileSystem
+	instance.AllowedRoots = nil
+}
+
+func (s *FsReadTestSuite) TestFileRead() {
+	s.Run("Reads the file with line numbers", func() {
+		result, err := FileRead.Function(map[string]interface{}{"path": "/project/README.md"})
+		s.NoError(err)
+		s.Equal("     1\t# Project\n     2\tHello world\n", result)
+	})
+	s.Run("Reads a range of lines", func() {
+		result, err := FileRead.Function(map[string]interface{}{"path": "/project/main.go", "start_line": float64(3), "end_line": float64(4)})
+		s.NoError(err)
+		s.Equal("     3\tfunc main() {\n     4\t\tprintln(\"hello\")\n"+
+			"[Showing lines 3-4 of 5, read more by calling file_read with start_line 5]\n", result)
+	})
+	s.Run("Start line out of range", func() {
+		result, err := FileRead.Function(map[string]interface{}{"path": "/project/main.go", "start_line": float64(10)})
+		s.NoError(err)
+		s.Equal("The file has 5 lines.", result)
+	})
+	s.Run("Truncates long lines", func() {
+		s.Require().NoError(afero.WriteFile(config.FileSystem, "/project/long.txt", []byte(strings.Repeat("a", maxLineLength+10)), 0644))
+		result, err := FileRead.Function(map[string]interface{}{"path": "/project/long.txt"})
+		s.NoError(err)
+		s.True(strings.HasSuffix(result, "a... [line truncated]\n"))
+	})
+	s.Run("Binary files are reported in the result", func() {
+		result, err := FileRead.Function(map[string]interface{}{"path": "/project/image.png"})
+		s.NoError(err)
+		s.Equal("/project/image.png is a binary file.", result)
+	})
+	s.Run("Directories are reported in the result", func() {
+		result, err := FileRead.Function(map[string]interface{}{"path": "/project/pkg"})
+		s.NoError(err)
+		s.Equal("/project/pkg is a directory, use file_find to list its files.", result)
+	})
+	s.Run("Missing files are reported in the result", func() {
+		result, err := FileRead.Function(map[string]interface{}{"path": "/project/missing.txt"})
+		s.NoError(err)
+		s.Equal("File /project/missing.txt does not exist.", result)
+	})
+}
+
+func (s *FsReadTestSuite) TestFileFind() {
+	s.Run("Finds files by name in any directory", func() {
+		result, err := FileFind.Function(map[string]interface{}{"directory": "/project", "pattern": "*.go"})
+		s.NoError(err)
+		s.Equal(`{"files":["main.go","pkg/util/util.go"]}`, result)
+	})
+	s.Run("Finds files by path", func() {
+		result, err := FileFind.Function(map[string]interface{}{"directory": "/project", "pattern": "pkg/**/*.go"})
+		s.NoError(err)
+		s.Equal(`{"files":["pkg/util/util.go"]}`, result)
+	})
+	s.Run("Respects negated .gitignore patterns", func() {
+		result, err := FileFind.Function(map[string]interface{}{"directory": "/project", "pattern": "*.log"})
+		s.NoError(err)
+		s.Equal(`{"files":["keep.log"]}`, result)
+	})
+	s.Run("Returns error for missing directory", func() {
+		_, err := FileFind.Function(map[string]interface{}{"directory": "/project/missing", "pattern": "*"})
+		s.Error(err)
+	})
+}
+
+func (s *FsReadTestSuite) TestFileGrep() {
+	s.Run("Finds matches skipping ignored, binary and .git files", func() {
+		result, err := FileGrep.Function(map[string]interface{}{"directory": "/project", "pattern": "hello"})
+		s.NoError(err)
+		s.Equal("keep.log:1:hello kept\n"+
+			"main.go:4:\tprintln(\"hello\")\n"+
+			"pkg/util/util.go:5:\treturn \"hello\"\n", result)
+	})
+	s.Run("Case-insensitive search with include pattern", func() {
+		result, err := FileGrep.Function(map[string]interface{}{"directory": "/project", "pattern": "hello", "include": "*.md", "case_insensitive": true})
+		s.NoError(err)
+		s.Equal("README.md:2:Hello world\n", result)
+	})
+	s.Run("Context lines", func() {
+		result, err := FileGrep.Function(map[string]interface{}{"directory": "/project", "pattern": "^func", "context_lines": float64(1)})
+		s.NoError(err)
+		s.Equal("main.go-2-\n"+
+			"main.go:3:func main() {\n"+
+			"main.go-4-\tprintln(\"hello\")\n"+
+			"--\n"+
+			"pkg/util/util.go-3-// Hello returns a greeting\n"+
+			"pkg/util/util.go:4:func Hello() string {\n"+
+			"pkg/util/util.go-5-\treturn \"hello\"\n", result)
+	})
+	s.Run("No matches", func() {
+		result, err := FileGrep.Function(map[string]interface{}{"directory": "/project", "pattern": "goodbye"})
+		s.NoError(err)
+		s.Equal("No matches found.", result)
+	})
+	s.Run("Invalid patterns are reported in the result", func() {
+		result, err := FileGrep.Function(map[string]interface{}{"directory": "/project", "pattern": "("})
+		s.NoError(err)
+		s.Equal("Invalid pattern: error parsing regexp: missing closing ): `(`.", result)
+	})
+}
+
+func TestFsRead(t *testing.T) {
+	suite.Run(t, new(FsReadTestSuite))
+}
@@ -0,0 +1,16 @@
+package fs
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/manusa/ai-cli/pkg/tools"
+)
+
+// resolvePath returns the absolute path (with symlinks resolved) if it's inside one of the allowed roots (the current
+// working directory if none is configured), or an access denied api.ToolResultError otherwise.
+func (p *Provider) resolvePath(path string) (string, error) {
+	resolved, err := tools.ResolvePath(path, p.AllowedRoots)
+	if err != nil {
+		log.Warn("fs access denied", "path", path, "roots", tools.AllowedRoots(p.AllowedRoots))
+	}
+	return resolved, err
+}
@@ -0,0 +1,121 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/stretchr/testify/suite"
+)
+
+type FsSandboxTestSuite struct {
+	suite.Suite
+	root    string
+	outside string
+}
+
+func (s *FsSandboxTestSuite) SetupTest() {
+	dir, err := filepath.EvalSymlinks(s.T().TempDir())
+	s.Require().NoError(err)
+	s.root = filepath.Join(dir, "root")
+	s.outside = filepath.Join(dir, "outside")
+	s.Require().NoError(os.MkdirAll(filepath.Join(s.root, "src"), 0755))
+	s.Require().NoError(os.MkdirAll(s.outside, 0755))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.root, "src", "main.go"), []byte("package main\n"), 0644))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.outside, "secret.txt"), []byte("secret\n"), 0644))
+	s.Require().NoError(os.Symlink(s.outside, filepath.Join(s.root, "link")))
+	instance.AllowedRoots = []string{s.root}
+}
+
+func (s *FsSandboxTestSuite) TearDownTest() {
+	instance.AllowedRoots = nil
+}
+
+func (s *FsSandboxTestSuite) TestResolvePath() {
+	s.Run("Allows paths inside the roots", func() {
+		resolved, err := instance.resolvePath(filepath.Join(s.root, "src", "main.go"))
+		s.NoError(err)
+		s.Equal(filepath.Join(s.root, "src", "main.go"), resolved)
+	})
+	s.Run("Allows the root itself", func() {
+		resolved, err := instance.resolvePath(s.root)
+		s.NoError(err)
+		s.Equal(s.root, resolved)
+	})
+	s.Run("Allows paths that don't exist yet", func() {
+		resolved, err := instance.resolvePath(filepath.Join(s.root, "new", "file.txt"))
+		s.NoError(err)
+		s.Equal(filepath.Join(s.root, "new", "file.txt"), resolved)
+	})
+	s.Run("Denies paths outside the roots", func() {
+		_, err := instance.resolvePath(filepath.Join(s.outside, "secret.txt"))
+		s.EqualError(err, "Access denied: "+filepath.Join(s.outside, "secret.txt")+" is outside the allowed directories ("+s.root+").")
+	})
+	s.Run("Denies .. traversal", func() {
+		_, err := instance.resolvePath(filepath.Join(s.root, "src", "..", "..", "outside", "secret.txt"))
+		s.Error(err)
+	})
+	s.Run("Denies symlinks pointing outside the roots", func() {
+		_, err := instance.resolvePath(filepath.Join(s.root, "link", "secret.txt"))
+		s.Error(err)
+	})
+	s.Run("Denies siblings sharing the root prefix", func() {
+		_, err := instance.resolvePath(s.root + "-other")
+		s.Error(err)
+	})
+	s.Run("Defaults to the current working directory", func() {
+		instance.AllowedRoots = nil
+		defer func() { instance.AllowedRoots = []string{s.root} }()
+		s.T().Chdir(s.root)
+		_, err := instance.resolvePath("src/main.go")
+		s.NoError(err)
+		_, err = instance.resolvePath("../outside/secret.txt")
+		s.Error(err)
+	})
+	s.Run("Supports multiple roots", func() {
+		instance.AllowedRoots = []string{s.root, s.outside}
+		defer func() { instance.AllowedRoots = []string{s.root} }()
+		_, err := instance.resolvePath(filepath.Join(s.root, "link", "secret.txt"))
+		s.NoError(err)
+	})
+}
+
+func (s *FsSandboxTestSuite) TestToolsDenyAccess() {
+	secret := filepath.Join(s.outside, "secret.txt")
+	for _, call := range []struct {
+		name   string
+		invoke func() (string, error)
+	}{
+		{"file_list", func() (string, error) { return FileList.Function(map[string]interface{}{"directory": s.outside}) }},
+		{"file_read", func() (string, error) { return FileRead.Function(map[string]interface{}{"path": secret}) }},
+		{"file_find", func() (string, error) {
+			return FileFind.Function(map[string]interface{}{"directory": s.outside, "pattern": "*"})
+		}},
+		{"file_grep", func() (string, error) {
+			return FileGrep.Function(map[string]interface{}{"directory": s.outside, "pattern": "secret"})
+		}},
+		{"file_write", func() (string, error) {
+			return FileWrite.Function(map[string]interface{}{"path": secret, "content": "leaked"})
+		}},
+		{"file_edit", func() (string, error) {
+			return FileEdit.Function(map[string]interface{}{"path": secret, "old_string": "secret", "new_string": "leaked"})
+		}},
+		{"file_delete", func() (string, error) { return FileDelete.Function(map[string]interface{}{"path": secret}) }},
+	} {
+		s.Run(call.name+" returns the denial as a tool result error", func() {
+			_, err := call.invoke()
+			s.ErrorAs(err, new(*api.ToolResultError))
+			s.ErrorContains(err, "Access denied: ")
+		})
+	}
+	s.Run("Files outside the roots are untouched", func() {
+		data, err := os.ReadFile(secret)
+		s.NoError(err)
+		s.Equal("secret\n", string(data))
+	})
+}
+
+func TestFsSandbox(t *testing.T) {
+	suite.Run(t, new(FsSandboxTestSuite))
+}
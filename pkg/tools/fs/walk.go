@@ -0,0 +1,161 @@
+package fs
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/spf13/afero"
+)
+
+// binaryCheckSize is the number of bytes checked to detect binary files (same heuristic as git)
+const binaryCheckSize = 8000
+
+// ignoreRule is a .gitignore pattern
+type ignoreRule struct {
+	// base is the directory containing the .gitignore file (relative to the walked directory, "" for the root)
+	base     string
+	pattern  *regexp.Regexp
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+func (r ignoreRule) matches(name string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if r.base != "" {
+		if !strings.HasPrefix(name, r.base+"/") {
+			return false
+		}
+		name = strings.TrimPrefix(name, r.base+"/")
+	}
+	return r.pattern.MatchString(name)
+}
+
+// parseIgnoreRules parses the .gitignore file contents
+func parseIgnoreRules(base, contents string) []ignoreRule {
+	var rules []ignoreRule
+	for _, line := range strings.Split(contents, "\n") {
+		line = strings.TrimRight(line, " \r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		// A pattern with a separator (other than a trailing one) is relative to the .gitignore directory
+		rule.anchored = strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		rule.pattern = globRegexp(line, rule.anchored)
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// globRegexp converts a glob pattern (supporting **) to a regular expression.
+// Non-anchored patterns match the file name in any directory.
+func globRegexp(pattern string, anchored bool) *regexp.Regexp {
+	sb := strings.Builder{}
+	sb.WriteString("^")
+	if !anchored {
+		sb.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			if end := strings.IndexByte(pattern[i:], ']'); end > 0 {
+				sb.WriteString(strings.Replace(pattern[i:i+end+1], "[!", "[^", 1))
+				i += end
+			} else {
+				sb.WriteString(regexp.QuoteMeta(string(c)))
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	compiled, err := regexp.Compile(sb.String())
+	if err != nil {
+		return regexp.MustCompile("^" + regexp.QuoteMeta(pattern) + "$")
+	}
+	return compiled
+}
+
+// walkFiles walks the regular files in the directory recursively (in lexical order) and calls fn with their path and
+// their name relative to the directory.
+// The .git directories, the files ignored by the .gitignore files, and the binary files are skipped.
+func walkFiles(directory string, fn func(path, name string) error) error {
+	var rules []ignoreRule
+	return afero.Walk(config.FileSystem, directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name, _ := filepath.Rel(directory, path)
+		name = filepath.ToSlash(name)
+		if info.IsDir() {
+			if path != directory && (info.Name() == ".git" || isIgnored(rules, name, true)) {
+				return filepath.SkipDir
+			}
+			if contents, err := afero.ReadFile(config.FileSystem, filepath.Join(path, ".gitignore")); err == nil {
+				base := name
+				if path == directory {
+					base = ""
+				}
+				rules = append(rules, parseIgnoreRules(base, string(contents))...)
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || isIgnored(rules, name, false) || isBinary(path) {
+			return nil
+		}
+		return fn(path, name)
+	})
+}
+
+// isIgnored returns true if the last rule matching the name ignores it
+func isIgnored(rules []ignoreRule, name string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		if rule.matches(name, isDir) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// isBinary returns true if the file contains a NUL byte in its first bytes
+func isBinary(path string) bool {
+	f, err := config.FileSystem.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+	head := make([]byte, binaryCheckSize)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return false
+	}
+	return bytes.IndexByte(head[:n], 0) >= 0
+}
@@ -0,0 +1,237 @@
+package fs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+)
+
+var FileWrite = &api.Tool{
+	Name: "file_write",
+	Description: "Write the provided content to a file, creating the file (and its parent directories) if it doesn't exist or replacing its content if it does. " +
+		"Prefer file_edit to change part of an existing file. " +
+		"Returns a unified diff of the changes.",
+	Destructive: true,
+	Parameters: map[string]api.ToolParameter{
+		"path": {
+			Type:        api.String,
+			Description: "The path of the file to write.",
+			Required:    true,
+		},
+		"content": {
+			Type:        api.String,
+			Description: "The complete content of the file.",
+			Required:    true,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		path, _ := args["path"].(string)
+		if path == "" {
+			return "", errors.New("path is required")
+		}
+		content, _ := args["content"].(string)
+		path, err := instance.resolvePath(path)
+		if err != nil {
+			return "", err
+		}
+		original, exists, invalid, err := readForWrite(path)
+		if invalid != "" || err != nil {
+			return invalid, err
+		}
+		if err = writeFile(path, content); err != nil {
+			return "", err
+		}
+		if !exists {
+			return fmt.Sprintf("File %s created.\n%s", path, unifiedDiff(path, "", content)), nil
+		}
+		if original == content {
+			return fmt.Sprintf("File %s unchanged.", path), nil
+		}
+		return fmt.Sprintf("File %s updated.\n%s", path, unifiedDiff(path, original, content)), nil
+	},
+}
+
+var FileEdit = &api.Tool{
+	Name: "file_edit",
+	Description: "Edit a text file by replacing an exact string with a new string. " +
+		"The old_string must match the file content exactly (including whitespace and indentation) and must be unique in the file, " +
+		"include enough surrounding lines to make it unique or set replace_all to replace every occurrence. " +
+		"Returns a unified diff of the changes.",
+	Destructive: true,
+	Parameters: map[string]api.ToolParameter{
+		"path": {
+			Type:        api.String,
+			Description: "The path of the file to edit.",
+			Required:    true,
+		},
+		"old_string": {
+			Type:        api.String,
+			Description: "The exact text to replace.",
+			Required:    true,
+		},
+		"new_string": {
+			Type:        api.String,
+			Description: "The text to replace old_string with.",
+			Required:    true,
+		},
+		"replace_all": {
+			Type:        api.Boolean,
+			Description: "Whether to replace every occurrence of old_string.",
+			Default:     false,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		path, _ := args["path"].(string)
+		if path == "" {
+			return "", errors.New("path is required")
+		}
+		oldString, _ := args["old_string"].(string)
+		newString, _ := args["new_string"].(string)
+		replaceAll, _ := args["replace_all"].(bool)
+		path, err := instance.resolvePath(path)
+		if err != nil {
+			return "", err
+		}
+		original, exists, invalid, err := readForWrite(path)
+		if invalid != "" || err != nil {
+			return invalid, err
+		}
+		if !exists {
+			return fmt.Sprintf("File %s does not exist, use file_write to create it.", path), nil
+		}
+		if oldString == "" {
+			return "The old_string can't be empty.", nil
+		}
+		if oldString == newString {
+			return "The old_string and new_string are identical, no changes to make.", nil
+		}
+		occurrences := strings.Count(original, oldString)
+		switch {
+		case occurrences == 0:
+			return fmt.Sprintf("The old_string was not found in %s, read the file and make sure it matches exactly (including whitespace).", path), nil
+		case occurrences > 1 && !replaceAll:
+			return fmt.Sprintf("The old_string was found %d times in %s, include more surrounding lines to make it unique or set replace_all to true.",
+				occurrences, path), nil
+		}
+		replacements := 1
+		if replaceAll {
+			replacements = occurrences
+		}
+		content := strings.Replace(original, oldString, newString, replacements)
+		if err = writeFile(path, content); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("File %s updated (%d replacements).\n%s", path, replacements, unifiedDiff(path, original, content)), nil
+	},
+}
+
+var FileDelete = &api.Tool{
+	Name:        "file_delete",
+	Description: "Delete a file. Returns a unified diff of the deleted content.",
+	Destructive: true,
+	Parameters: map[string]api.ToolParameter{
+		"path": {
+			Type:        api.String,
+			Description: "The path of the file to delete.",
+			Required:    true,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		path, _ := args["path"].(string)
+		if path == "" {
+			return "", errors.New("path is required")
+		}
+		path, err := instance.resolvePath(path)
+		if err != nil {
+			return "", err
+		}
+		info, err := config.FileSystem.Stat(path)
+		if err != nil {
+			return statErrorResult(path, err), nil
+		}
+		if info.IsDir() {
+			return fmt.Sprintf("%s is a directory, only files can be deleted.", path), nil
+		}
+		diff := fmt.Sprintf("Binary file %s deleted.", path)
+		if !isBinary(path) {
+			original, err := afero.ReadFile(config.FileSystem, path)
+			if err != nil {
+				return "", err
+			}
+			diff = unifiedDiff(path, string(original), "")
+		}
+		if err = config.FileSystem.Remove(path); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("File %s deleted.\n%s", path, diff), nil
+	},
+}
+
+// readForWrite reads the current content of a text file before it's modified.
+// The invalid result (if any) explains to the model why the file can't be modified.
+func readForWrite(path string) (content string, exists bool, invalid string, err error) {
+	info, err := config.FileSystem.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, "", nil
+	}
+	if err != nil {
+		return "", false, statErrorResult(path, err), nil
+	}
+	if invalid = checkTextFile(path, info); invalid != "" {
+		return "", false, invalid, nil
+	}
+	data, err := afero.ReadFile(config.FileSystem, path)
+	return string(data), true, "", err
+}
+
+// writeFile writes the content to the file preserving its permissions, parent directories are created if needed
+func writeFile(path, content string) error {
+	perm := os.FileMode(0644)
+	if info, err := config.FileSystem.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := config.FileSystem.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return afero.WriteFile(config.FileSystem, path, []byte(content), perm)
+}
+
+// unifiedDiff returns the changes in the unified diff format (git style, /dev/null for created or deleted files)
+func unifiedDiff(path, original, modified string) string {
+	name := strings.TrimPrefix(filepath.ToSlash(path), "/")
+	fromFile, toFile := "a/"+name, "b/"+name
+	if original == "" {
+		fromFile = "/dev/null"
+	}
+	if modified == "" {
+		toFile = "/dev/null"
+	}
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(original),
+		B:        splitLines(modified),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	return diff
+}
+
+// splitLines splits the text keeping the line endings (a missing final line ending is added)
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
@@ -0,0 +1,161 @@
+package fs
+
+import (
+	"testing"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/suite"
+)
+
+type FsWriteTestSuite struct {
+	suite.Suite
+	originalFileSystem afero.Fs
+}
+
+func (s *FsWriteTestSuite) SetupTest() {
+	s.originalFileSystem = config.FileSystem
+	config.FileSystem = afero.NewMemMapFs()
+	instance.AllowedRoots = []string{"/project"}
+	s.Require().NoError(afero.WriteFile(config.FileSystem, "/project/config.yaml", []byte("port: 8080\nhost: localhost\nport: 8080\n"), 0600))
+}
+
+func (s *FsWriteTestSuite) TearDownTest() {
+	config.FileSystem = s.originalFileSystem
+	instance.AllowedRoots = nil
+}
+
+func (s *FsWriteTestSuite) content(path string) string {
+	data, err := afero.ReadFile(config.FileSystem, path)
+	s.Require().NoError(err)
+	return string(data)
+}
+
+func (s *FsWriteTestSuite) TestFileWrite() {
+	s.Run("Creates the file and its parent directories", func() {
+		result, err := FileWrite.Function(map[string]interface{}{"path": "/project/new/file.txt", "content": "hello\n"})
+		s.NoError(err)
+		s.Equal("File /project/new/file.txt created.\n"+
+			"--- /dev/null\n"+
+			"+++ b/project/new/file.txt\n"+
+			"@@ -0,0 +1 @@\n"+
+			"+hello\n", result)
+		s.Equal("hello\n", s.content("/project/new/file.txt"))
+	})
+	s.Run("Replaces the file content preserving its permissions", func() {
+		result, err := FileWrite.Function(map[string]interface{}{"path": "/project/config.yaml", "content": "port: 9090\n"})
+		s.NoError(err)
+		s.Equal("File /project/config.yaml updated.\n"+
+			"--- a/project/config.yaml\n"+
+			"+++ b/project/config.yaml\n"+
+			"@@ -1,3 +1 @@\n"+
+			"-port: 8080\n"+
+			"-host: localhost\n"+
+			"-port: 8080\n"+
+			"+port: 9090\n", result)
+		info, _ := config.FileSystem.Stat("/project/config.yaml")
+		s.Equal("-rw-------", info.Mode().String())
+	})
+	s.Run("Unchanged content", func() {
+		result, err := FileWrite.Function(map[string]interface{}{"path": "/project/config.yaml", "content": "port: 9090\n"})
+		s.NoError(err)
+		s.Equal("File /project/config.yaml unchanged.", result)
+	})
+	s.Run("Directories are reported in the result", func() {
+		s.Require().NoError(config.FileSystem.MkdirAll("/project/pkg", 0755))
+		result, err := FileWrite.Function(map[string]interface{}{"path": "/project/pkg", "content": "hello\n"})
+		s.NoError(err)
+		s.Equal("/project/pkg is a directory, use file_find to list its files.", result)
+	})
+}
+
+func (s *FsWriteTestSuite) TestFileEdit() {
+	s.Run("Rejects ambiguous replacements", func() {
+		result, err := FileEdit.Function(map[string]interface{}{"path": "/project/config.yaml", "old_string": "port: 8080", "new_string": "port: 9090"})
+		s.NoError(err)
+		s.Equal("The old_string was found 2 times in /project/config.yaml, include more surrounding lines to make it unique or set replace_all to true.", result)
+		s.Equal("port: 8080\nhost: localhost\nport: 8080\n", s.content("/project/config.yaml"))
+	})
+	s.Run("Replaces a unique string", func() {
+		result, err := FileEdit.Function(map[string]interface{}{"path": "/project/config.yaml", "old_string": "host: localhost", "new_string": "host: 0.0.0.0"})
+		s.NoError(err)
+		s.Equal("File /project/config.yaml updated (1 replacements).\n"+
+			"--- a/project/config.yaml\n"+
+			"+++ b/project/config.yaml\n"+
+			"@@ -1,3 +1,3 @@\n"+
+			" port: 8080\n"+
+			"-host: localhost\n"+
+			"+host: 0.0.0.0\n"+
+			" port: 8080\n", result)
+	})
+	s.Run("Replaces all occurrences", func() {
+		result, err := FileEdit.Function(map[string]interface{}{"path": "/project/config.yaml", "old_string": "8080", "new_string": "9090", "replace_all": true})
+		s.NoError(err)
+		s.Contains(result, "File /project/config.yaml updated (2 replacements).\n")
+		s.Equal("port: 9090\nhost: 0.0.0.0\nport: 9090\n", s.content("/project/config.yaml"))
+	})
+	s.Run("String not found", func() {
+		result, err := FileEdit.Function(map[string]interface{}{"path": "/project/config.yaml", "old_string": "missing", "new_string": "found"})
+		s.NoError(err)
+		s.Equal("The old_string was not found in /project/config.yaml, read the file and make sure it matches exactly (including whitespace).", result)
+	})
+	s.Run("Missing file", func() {
+		result, err := FileEdit.Function(map[string]interface{}{"path": "/project/missing.yaml", "old_string": "a", "new_string": "b"})
+		s.NoError(err)
+		s.Equal("File /project/missing.yaml does not exist, use file_write to create it.", result)
+	})
+}
+
+func (s *FsWriteTestSuite) TestFileDelete() {
+	s.Run("Deletes the file", func() {
+		result, err := FileDelete.Function(map[string]interface{}{"path": "/project/config.yaml"})
+		s.NoError(err)
+		s.Equal("File /project/config.yaml deleted.\n"+
+			"--- a/project/config.yaml\n"+
+			"+++ /dev/null\n"+
+			"@@ -1,3 +0,0 @@\n"+
+			"-port: 8080\n"+
+			"-host: localhost\n"+
+			"-port: 8080\n", result)
+		exists, _ := afero.Exists(config.FileSystem, "/project/config.yaml")
+		s.False(exists)
+	})
+	s.Run("Missing files are reported in the result", func() {
+		result, err := FileDelete.Function(map[string]interface{}{"path": "/project/missing.yaml"})
+		s.NoError(err)
+		s.Equal("File /project/missing.yaml does not exist.", result)
+	})
+	s.Run("Directories are reported in the result", func() {
+		s.Require().NoError(config.FileSystem.MkdirAll("/project/pkg", 0755))
+		result, err := FileDelete.Function(map[string]interface{}{"path": "/project/pkg"})
+		s.NoError(err)
+		s.Equal("/project/pkg is a directory, only files can be deleted.", result)
+	})
+}
+
+func (s *FsWriteTestSuite) TestGetTools() {
+	toolNames := func(parameters api.ToolsParameters) []string {
+		p := &Provider{}
+		p.ToolsParameters = parameters
+		var names []string
+		for _, t := range p.GetTools(s.T().Context()) {
+			names = append(names, t.Name)
+		}
+		return names
+	}
+	s.Run("All tools by default", func() {
+		s.Equal([]string{"file_list", "file_read", "file_find", "file_grep", "file_write", "file_edit", "file_delete"}, toolNames(api.ToolsParameters{}))
+	})
+	s.Run("Read-only hides write tools", func() {
+		s.Equal([]string{"file_list", "file_read", "file_find", "file_grep"}, toolNames(api.ToolsParameters{ReadOnly: utils.Ptr(true)}))
+	})
+	s.Run("Disable destructive hides write tools", func() {
+		s.Equal([]string{"file_list", "file_read", "file_find", "file_grep"}, toolNames(api.ToolsParameters{DisableDestructive: utils.Ptr(true)}))
+	})
+}
+
+func TestFsWrite(t *testing.T) {
+	suite.Run(t, new(FsWriteTestSuite))
+}
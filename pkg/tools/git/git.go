@@ -0,0 +1,120 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/tools"
+)
+
+type Provider struct {
+	api.BasicToolsProvider
+}
+
+var _ api.ToolsProvider = &Provider{}
+
+func (p *Provider) Initialize(ctx context.Context) {
+	if cfg := config.GetConfig(ctx); cfg != nil {
+		p.ToolsParameters = cfg.ToolsParameters(p.Attributes().Name())
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		p.IsAvailableReason = "git is not installed"
+		return
+	}
+	if output, err := exec.Command("git", "rev-parse", "--is-inside-work-tree").Output(); err != nil || strings.TrimSpace(string(output)) != "true" {
+		p.IsAvailableReason = "the current directory is not inside a git work tree"
+		return
+	}
+	p.Available = true
+	p.IsAvailableReason = "the current directory is inside a git work tree"
+}
+
+// GetTools returns the git tools, the write tools are hidden for read-only toolsets and git_branch_delete if
+// destructive tools are disabled.
+func (p *Provider) GetTools(_ context.Context) []*api.Tool {
+	gitTools := []*api.Tool{
+		GitStatus,
+		GitDiff,
+		GitLog,
+		GitShow,
+		GitBlame,
+		GitBranchList,
+	}
+	if p.ReadOnly != nil && *p.ReadOnly {
+		return gitTools
+	}
+	gitTools = append(gitTools, GitCommit, GitCheckout, GitBranchCreate)
+	if p.DisableDestructive != nil && *p.DisableDestructive {
+		return gitTools
+	}
+	return append(gitTools, GitBranchDelete)
+}
+
+// git runs the git command in the current working directory and returns its output.
+// The error message of a failed git command is returned as the result.
+func git(args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"--no-pager", "-c", "color.ui=false", "-c", "core.quotepath=false"}, args...)...)
+	// Never wait for user input (credentials, commit message editor...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_EDITOR=true")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			// Some commands (e.g. commit with nothing to commit) report the failure in the standard output
+			message = strings.TrimSpace(stdout.String())
+		}
+		return fmt.Sprintf("git %s failed (exit code %d):\n%s", args[0], exitErr.ExitCode(), message), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// invalidArgument returns an error message if any of the values would be interpreted as a git option
+func invalidArgument(values ...string) string {
+	for _, value := range values {
+		if strings.HasPrefix(value, "-") {
+			return fmt.Sprintf("Invalid argument '%s': revisions, branch names and paths can't start with '-'.", value)
+		}
+	}
+	return ""
+}
+
+// stringArgs returns the strings of an array argument
+func stringArgs(args map[string]interface{}, name string) []string {
+	var values []string
+	if a, ok := args[name].([]interface{}); ok {
+		for _, v := range a {
+			if s, ok := v.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+var instance = &Provider{
+	api.BasicToolsProvider{
+		BasicToolsAttributes: api.BasicToolsAttributes{
+			BasicFeatureAttributes: api.BasicFeatureAttributes{
+				FeatureName:        "git",
+				FeatureDescription: "Provides access to the git repository of the current directory, allowing inspection of its status, changes and history, and committing changes.",
+			},
+		},
+	},
+}
+
+func init() {
+	tools.Register(instance)
+}
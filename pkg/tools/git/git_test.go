@@ -0,0 +1,103 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+// repositorySuite creates a temporary git repository and makes it the current working directory
+type repositorySuite struct {
+	suite.Suite
+	dir string
+}
+
+func (s *repositorySuite) SetupTest() {
+	if _, err := exec.LookPath("git"); err != nil {
+		s.T().Skip("git is not installed")
+	}
+	// Isolate the repository from the user and system configuration
+	s.T().Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	s.T().Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	s.dir = s.T().TempDir()
+	s.T().Chdir(s.dir)
+	s.git("init", "--initial-branch", "main")
+	s.git("config", "user.name", "Alice")
+	s.git("config", "user.email", "alice@example.com")
+	s.writeFile("README.md", "# Project\n")
+	s.commit("Alice", "2024-01-01T10:00:00", "Initial commit")
+	s.writeFile("main.go", "package main\n\nfunc main() {\n}\n")
+	s.commit("Bob", "2024-02-01T10:00:00", "Add main")
+	s.writeFile("README.md", "# Project\n\nA sample project.\n")
+	s.commit("Alice", "2024-03-01T10:00:00", "Describe the project")
+}
+
+func (s *repositorySuite) git(args ...string) string {
+	output, err := exec.Command("git", args...).CombinedOutput()
+	s.Require().NoError(err, string(output))
+	return string(output)
+}
+
+func (s *repositorySuite) writeFile(name, content string) {
+	s.Require().NoError(os.MkdirAll(filepath.Dir(filepath.Join(s.dir, name)), 0755))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, name), []byte(content), 0644))
+}
+
+func (s *repositorySuite) commit(author, date, message string) {
+	s.git("add", "--all")
+	cmd := exec.Command("git", "commit", "--message", message, "--author", author+" <"+author+"@example.com>")
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+	output, err := cmd.CombinedOutput()
+	s.Require().NoError(err, string(output))
+}
+
+type GitTestSuite struct {
+	repositorySuite
+}
+
+func (s *GitTestSuite) TestInitialize() {
+	s.Run("Available inside a git work tree", func() {
+		p := &Provider{}
+		p.Initialize(s.T().Context())
+		s.True(p.IsAvailable())
+		s.Equal("the current directory is inside a git work tree", p.Reason())
+	})
+	s.Run("Not available outside a git work tree", func() {
+		s.T().Chdir(s.T().TempDir())
+		p := &Provider{}
+		p.Initialize(s.T().Context())
+		s.False(p.IsAvailable())
+		s.Equal("the current directory is not inside a git work tree", p.Reason())
+	})
+}
+
+func (s *GitTestSuite) TestGetTools() {
+	toolNames := func(parameters api.ToolsParameters) []string {
+		p := &Provider{}
+		p.ToolsParameters = parameters
+		var names []string
+		for _, t := range p.GetTools(s.T().Context()) {
+			names = append(names, t.Name)
+		}
+		return names
+	}
+	readTools := []string{"git_status", "git_diff", "git_log", "git_show", "git_blame", "git_branch_list"}
+	s.Run("All tools by default", func() {
+		s.Equal(append(readTools, "git_commit", "git_checkout", "git_branch_create", "git_branch_delete"), toolNames(api.ToolsParameters{}))
+	})
+	s.Run("Read-only hides write tools", func() {
+		s.Equal(readTools, toolNames(api.ToolsParameters{ReadOnly: utils.Ptr(true)}))
+	})
+	s.Run("Disable destructive hides git_branch_delete", func() {
+		s.Equal(append(readTools, "git_commit", "git_checkout", "git_branch_create"), toolNames(api.ToolsParameters{DisableDestructive: utils.Ptr(true)}))
+	})
+}
+
+func TestGit(t *testing.T) {
+	suite.Run(t, new(GitTestSuite))
+}
@@ -0,0 +1,267 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/utils"
+)
+
+const (
+	// defaultLogCount is the number of commits returned by git_log if no max_count is provided
+	defaultLogCount = 20
+	// maxLogCount is the maximum number of commits returned by git_log
+	maxLogCount = 500
+	// logFormat is the git_log format (abbreviated hash, date, author, and subject)
+	logFormat = "--format=%h %ad %an%d%n    %s"
+	// logDateFormat is the date format of git_log and git_blame
+	logDateFormat = "--date=format:%Y-%m-%d %H:%M"
+)
+
+var GitStatus = &api.Tool{
+	Name:        "git_status",
+	Description: "Show the status of the git repository: the current branch, its tracking status, and the staged, unstaged and untracked changes.",
+	ReadOnly:    true,
+	Parameters:  map[string]api.ToolParameter{},
+	Function: func(args map[string]interface{}) (string, error) {
+		return git("status")
+	},
+}
+
+var GitDiff = &api.Tool{
+	Name: "git_diff",
+	Description: "Show the changes of the git repository in the unified diff format. " +
+		"By default shows the unstaged changes, set staged to show the changes staged for the next commit, " +
+		"or provide from (and optionally to) to show the changes between revisions (or between a revision and the working tree).",
+	ReadOnly: true,
+	Parameters: map[string]api.ToolParameter{
+		"staged": {
+			Type:        api.Boolean,
+			Description: "Whether to show the changes staged for the next commit.",
+			Default:     false,
+		},
+		"from": {
+			Type:        api.String,
+			Description: "The revision to compare from (e.g. a commit hash, branch, tag, or HEAD~3).",
+		},
+		"to": {
+			Type:        api.String,
+			Description: "The revision to compare to (requires from). If not provided, the working tree is used.",
+		},
+		"paths": {
+			Type:        api.Array,
+			Description: "Limit the diff to these paths.",
+			Items:       &api.ToolParameter{Type: api.String},
+		},
+		"stat": {
+			Type:        api.Boolean,
+			Description: "Whether to show a summary of the changed files instead of the complete diff.",
+			Default:     false,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		from, _ := args["from"].(string)
+		to, _ := args["to"].(string)
+		paths := stringArgs(args, "paths")
+		if to != "" && from == "" {
+			return "The to revision requires a from revision.", nil
+		}
+		if invalid := invalidArgument(append([]string{from, to}, paths...)...); invalid != "" {
+			return invalid, nil
+		}
+		gitArgs := []string{"diff"}
+		if staged, _ := args["staged"].(bool); staged {
+			gitArgs = append(gitArgs, "--cached")
+		}
+		if stat, _ := args["stat"].(bool); stat {
+			gitArgs = append(gitArgs, "--stat")
+		}
+		for _, revision := range []string{from, to} {
+			if revision != "" {
+				gitArgs = append(gitArgs, revision)
+			}
+		}
+		output, err := git(append(append(gitArgs, "--"), paths...)...)
+		if err == nil && output == "" {
+			return "No changes.", nil
+		}
+		return output, err
+	},
+}
+
+var GitLog = &api.Tool{
+	Name: "git_log",
+	Description: "Show the commit history of the git repository (most recent first), optionally filtered by revision range, date, author, message or path. " +
+		"Returns the abbreviated hash, date, author, references and subject of each commit. " +
+		fmt.Sprintf("If no max_count is provided, up to %d commits are returned.", defaultLogCount),
+	ReadOnly: true,
+	Parameters: map[string]api.ToolParameter{
+		"revision": {
+			Type:        api.String,
+			Description: "The revision or revision range to show (e.g. main, v1.0..HEAD). If not provided, the current branch is used.",
+		},
+		"max_count": {
+			Type:        api.Integer,
+			Description: "The maximum number of commits to return.",
+			Default:     defaultLogCount,
+			Minimum:     utils.Ptr(1.0),
+			Maximum:     utils.Ptr(float64(maxLogCount)),
+		},
+		"since": {
+			Type:        api.String,
+			Description: "Only show commits more recent than this date (e.g. 2024-01-31, last friday, 2 weeks ago).",
+		},
+		"until": {
+			Type:        api.String,
+			Description: "Only show commits older than this date.",
+		},
+		"author": {
+			Type:        api.String,
+			Description: "Only show commits whose author matches this pattern (name or email).",
+		},
+		"grep": {
+			Type:        api.String,
+			Description: "Only show commits whose message matches this regular expression.",
+		},
+		"paths": {
+			Type:        api.Array,
+			Description: "Only show commits that touched these paths.",
+			Items:       &api.ToolParameter{Type: api.String},
+		},
+		"stat": {
+			Type:        api.Boolean,
+			Description: "Whether to include the files changed by each commit.",
+			Default:     false,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		revision, _ := args["revision"].(string)
+		paths := stringArgs(args, "paths")
+		if invalid := invalidArgument(append([]string{revision}, paths...)...); invalid != "" {
+			return invalid, nil
+		}
+		maxCount := defaultLogCount
+		if m, ok := args["max_count"].(float64); ok && m >= 1 {
+			maxCount = min(int(m), maxLogCount)
+		}
+		gitArgs := []string{"log", fmt.Sprintf("--max-count=%d", maxCount), logFormat, logDateFormat}
+		for _, filter := range []string{"since", "until", "author", "grep"} {
+			if value, ok := args[filter].(string); ok && value != "" {
+				gitArgs = append(gitArgs, fmt.Sprintf("--%s=%s", filter, value))
+			}
+		}
+		if stat, _ := args["stat"].(bool); stat {
+			gitArgs = append(gitArgs, "--stat")
+		}
+		if revision != "" {
+			gitArgs = append(gitArgs, revision)
+		}
+		output, err := git(append(append(gitArgs, "--"), paths...)...)
+		if err == nil && output == "" {
+			return "No commits found.", nil
+		}
+		return output, err
+	},
+}
+
+var GitShow = &api.Tool{
+	Name:        "git_show",
+	Description: "Show a commit of the git repository: its metadata, message and changes in the unified diff format.",
+	ReadOnly:    true,
+	Parameters: map[string]api.ToolParameter{
+		"revision": {
+			Type:        api.String,
+			Description: "The commit to show (e.g. a commit hash, branch, tag, or HEAD~1).",
+			Default:     "HEAD",
+		},
+		"paths": {
+			Type:        api.Array,
+			Description: "Limit the changes to these paths.",
+			Items:       &api.ToolParameter{Type: api.String},
+		},
+		"stat": {
+			Type:        api.Boolean,
+			Description: "Whether to show a summary of the changed files instead of the complete diff.",
+			Default:     false,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		revision := "HEAD"
+		if r, ok := args["revision"].(string); ok && r != "" {
+			revision = r
+		}
+		paths := stringArgs(args, "paths")
+		if invalid := invalidArgument(append([]string{revision}, paths...)...); invalid != "" {
+			return invalid, nil
+		}
+		gitArgs := []string{"show", logDateFormat}
+		if stat, _ := args["stat"].(bool); stat {
+			gitArgs = append(gitArgs, "--stat")
+		}
+		return git(append(append(gitArgs, revision, "--"), paths...)...)
+	},
+}
+
+var GitBlame = &api.Tool{
+	Name: "git_blame",
+	Description: "Show the last commit (abbreviated hash, author and date) that modified each line of a file, optionally limited to a range of lines. " +
+		"Use git_show to see the complete commit.",
+	ReadOnly: true,
+	Parameters: map[string]api.ToolParameter{
+		"path": {
+			Type:        api.String,
+			Description: "The path of the file to blame.",
+			Required:    true,
+		},
+		"revision": {
+			Type:        api.String,
+			Description: "The revision of the file to blame. If not provided, the working tree version is used.",
+		},
+		"start_line": {
+			Type:        api.Integer,
+			Description: "The first line to blame (1-based).",
+			Minimum:     utils.Ptr(1.0),
+		},
+		"end_line": {
+			Type:        api.Integer,
+			Description: "The last line to blame (inclusive).",
+			Minimum:     utils.Ptr(1.0),
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		path, _ := args["path"].(string)
+		if path == "" {
+			return "", errors.New("path is required")
+		}
+		revision, _ := args["revision"].(string)
+		if invalid := invalidArgument(path, revision); invalid != "" {
+			return invalid, nil
+		}
+		gitArgs := []string{"blame", logDateFormat}
+		startLine, _ := args["start_line"].(float64)
+		endLine, _ := args["end_line"].(float64)
+		switch {
+		case startLine >= 1 && endLine >= 1:
+			gitArgs = append(gitArgs, fmt.Sprintf("-L%d,%d", int(startLine), int(endLine)))
+		case startLine >= 1:
+			gitArgs = append(gitArgs, fmt.Sprintf("-L%d,", int(startLine)))
+		case endLine >= 1:
+			gitArgs = append(gitArgs, fmt.Sprintf("-L1,%d", int(endLine)))
+		}
+		if revision != "" {
+			gitArgs = append(gitArgs, revision)
+		}
+		return git(append(gitArgs, "--", path)...)
+	},
+}
+
+var GitBranchList = &api.Tool{
+	Name:        "git_branch_list",
+	Description: "List the local and remote-tracking branches of the git repository with their last commit, the current branch is marked with *.",
+	ReadOnly:    true,
+	Parameters:  map[string]api.ToolParameter{},
+	Function: func(args map[string]interface{}) (string, error) {
+		return git("branch", "--all", "-vv")
+	},
+}
@@ -0,0 +1,172 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GitReadTestSuite struct {
+	repositorySuite
+}
+
+func (s *GitReadTestSuite) TestGitStatus() {
+	s.writeFile("main.go", "package main\n")
+	s.writeFile("new.txt", "new\n")
+	result, err := GitStatus.Function(map[string]interface{}{})
+	s.NoError(err)
+	s.Contains(result, "On branch main")
+	s.Contains(result, "modified:   main.go")
+	s.Contains(result, "new.txt")
+}
+
+func (s *GitReadTestSuite) TestGitDiff() {
+	s.writeFile("main.go", "package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n")
+	s.Run("Shows unstaged changes", func() {
+		result, err := GitDiff.Function(map[string]interface{}{})
+		s.NoError(err)
+		s.Contains(result, "+\tprintln(\"hello\")")
+	})
+	s.Run("Shows no staged changes", func() {
+		result, err := GitDiff.Function(map[string]interface{}{"staged": true})
+		s.NoError(err)
+		s.Equal("No changes.", result)
+	})
+	s.Run("Shows staged changes", func() {
+		s.git("add", "main.go")
+		result, err := GitDiff.Function(map[string]interface{}{"staged": true})
+		s.NoError(err)
+		s.Contains(result, "+\tprintln(\"hello\")")
+	})
+	s.Run("Shows changes between revisions", func() {
+		result, err := GitDiff.Function(map[string]interface{}{"from": "HEAD~2", "to": "HEAD~1", "stat": true})
+		s.NoError(err)
+		s.Contains(result, "main.go | 4 ++++")
+		s.NotContains(result, "README.md")
+	})
+	s.Run("Limits changes to paths", func() {
+		result, err := GitDiff.Function(map[string]interface{}{"from": "HEAD~2", "paths": []interface{}{"README.md"}})
+		s.NoError(err)
+		s.Contains(result, "+A sample project.")
+		s.NotContains(result, "main.go")
+	})
+	s.Run("Requires from with to", func() {
+		result, err := GitDiff.Function(map[string]interface{}{"to": "HEAD"})
+		s.NoError(err)
+		s.Equal("The to revision requires a from revision.", result)
+	})
+	s.Run("Rejects options", func() {
+		result, err := GitDiff.Function(map[string]interface{}{"from": "--output=/tmp/diff"})
+		s.NoError(err)
+		s.Equal("Invalid argument '--output=/tmp/diff': revisions, branch names and paths can't start with '-'.", result)
+	})
+}
+
+func (s *GitReadTestSuite) TestGitLog() {
+	s.Run("Shows the history", func() {
+		result, err := GitLog.Function(map[string]interface{}{})
+		s.NoError(err)
+		s.Regexp(`^[0-9a-f]+ 2024-03-01 10:00 Alice \(HEAD -> main\)\n    Describe the project\n`, result)
+		s.Contains(result, "2024-02-01 10:00 Bob\n    Add main\n")
+		s.Contains(result, "2024-01-01 10:00 Alice\n    Initial commit\n")
+	})
+	s.Run("Limits the number of commits", func() {
+		result, err := GitLog.Function(map[string]interface{}{"max_count": float64(1)})
+		s.NoError(err)
+		s.Contains(result, "Describe the project")
+		s.NotContains(result, "Add main")
+	})
+	s.Run("Filters by author", func() {
+		result, err := GitLog.Function(map[string]interface{}{"author": "Bob"})
+		s.NoError(err)
+		s.Contains(result, "Add main")
+		s.NotContains(result, "Initial commit")
+	})
+	s.Run("Filters by date", func() {
+		result, err := GitLog.Function(map[string]interface{}{"since": "2024-01-15", "until": "2024-02-15"})
+		s.NoError(err)
+		s.Contains(result, "Add main")
+		s.NotContains(result, "Initial commit")
+		s.NotContains(result, "Describe the project")
+	})
+	s.Run("Filters by message", func() {
+		result, err := GitLog.Function(map[string]interface{}{"grep": "^Describe"})
+		s.NoError(err)
+		s.Contains(result, "Describe the project")
+		s.NotContains(result, "Add main")
+	})
+	s.Run("Filters by path", func() {
+		result, err := GitLog.Function(map[string]interface{}{"paths": []interface{}{"README.md"}})
+		s.NoError(err)
+		s.Contains(result, "Initial commit")
+		s.NotContains(result, "Add main")
+	})
+	s.Run("Filters by revision range", func() {
+		result, err := GitLog.Function(map[string]interface{}{"revision": "HEAD~1..HEAD"})
+		s.NoError(err)
+		s.Contains(result, "Describe the project")
+		s.NotContains(result, "Add main")
+	})
+	s.Run("Shows no commits", func() {
+		result, err := GitLog.Function(map[string]interface{}{"author": "Carol"})
+		s.NoError(err)
+		s.Equal("No commits found.", result)
+	})
+	s.Run("Returns the git error for unknown revisions", func() {
+		result, err := GitLog.Function(map[string]interface{}{"revision": "unknown"})
+		s.NoError(err)
+		s.Contains(result, "git log failed (exit code 128):\nfatal: bad revision 'unknown'")
+	})
+}
+
+func (s *GitReadTestSuite) TestGitShow() {
+	s.Run("Shows the HEAD commit by default", func() {
+		result, err := GitShow.Function(map[string]interface{}{})
+		s.NoError(err)
+		s.Contains(result, "Author: Alice <Alice@example.com>")
+		s.Contains(result, "Date:   2024-03-01 10:00")
+		s.Contains(result, "+A sample project.")
+	})
+	s.Run("Shows the provided revision", func() {
+		result, err := GitShow.Function(map[string]interface{}{"revision": "HEAD~1", "stat": true})
+		s.NoError(err)
+		s.Contains(result, "Add main")
+		s.Contains(result, "main.go | 4 ++++")
+	})
+}
+
+func (s *GitReadTestSuite) TestGitBlame() {
+	s.Run("Blames every line", func() {
+		result, err := GitBlame.Function(map[string]interface{}{"path": "README.md"})
+		s.NoError(err)
+		s.Regexp(`(?m)^[0-9a-f^]+ \(Alice +2024-01-01 10:00 1\) # Project$`, result)
+		s.Regexp(`(?m)^[0-9a-f]+ \(Alice +2024-03-01 10:00 3\) A sample project.$`, result)
+	})
+	s.Run("Blames a range of lines", func() {
+		result, err := GitBlame.Function(map[string]interface{}{"path": "README.md", "start_line": float64(3), "end_line": float64(3)})
+		s.NoError(err)
+		s.Contains(result, "A sample project.")
+		s.NotContains(result, "# Project")
+	})
+	s.Run("Blames a revision", func() {
+		result, err := GitBlame.Function(map[string]interface{}{"path": "README.md", "revision": "HEAD~1"})
+		s.NoError(err)
+		s.NotContains(result, "A sample project.")
+	})
+	s.Run("Returns error for missing path", func() {
+		_, err := GitBlame.Function(map[string]interface{}{})
+		s.EqualError(err, "path is required")
+	})
+}
+
+func (s *GitReadTestSuite) TestGitBranchList() {
+	s.git("branch", "feature")
+	result, err := GitBranchList.Function(map[string]interface{}{})
+	s.NoError(err)
+	s.Regexp(`(?m)^  feature +[0-9a-f]+ Describe the project$`, result)
+	s.Regexp(`(?m)^\* main +[0-9a-f]+ Describe the project$`, result)
+}
+
+func TestGitRead(t *testing.T) {
+	suite.Run(t, new(GitReadTestSuite))
+}
@@ -0,0 +1,155 @@
+package git
+
+import (
+	"errors"
+
+	"github.com/manusa/ai-cli/pkg/api"
+)
+
+var GitCommit = &api.Tool{
+	Name: "git_commit",
+	Description: "Record the staged changes in a new commit of the git repository. " +
+		"Provide paths to stage them before committing, or set all to stage every modified and deleted tracked file.",
+	Parameters: map[string]api.ToolParameter{
+		"message": {
+			Type:        api.String,
+			Description: "The commit message.",
+			Required:    true,
+		},
+		"paths": {
+			Type:        api.Array,
+			Description: "The paths to stage before committing (new files are added too).",
+			Items:       &api.ToolParameter{Type: api.String},
+		},
+		"all": {
+			Type:        api.Boolean,
+			Description: "Whether to stage every modified and deleted tracked file before committing.",
+			Default:     false,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		message, _ := args["message"].(string)
+		if message == "" {
+			return "", errors.New("message is required")
+		}
+		paths := stringArgs(args, "paths")
+		if invalid := invalidArgument(paths...); invalid != "" {
+			return invalid, nil
+		}
+		if len(paths) > 0 {
+			if output, err := git(append([]string{"add", "--"}, paths...)...); err != nil || output != "" {
+				return output, err
+			}
+		}
+		gitArgs := []string{"commit", "--message", message}
+		if all, _ := args["all"].(bool); all {
+			gitArgs = append(gitArgs, "--all")
+		}
+		return git(gitArgs...)
+	},
+}
+
+var GitCheckout = &api.Tool{
+	Name: "git_checkout",
+	Description: "Switch the working tree of the git repository to a branch (or a revision in detached HEAD mode). " +
+		"Set create to create the branch first. " +
+		"Local changes are kept, the checkout fails if they would be overwritten.",
+	Parameters: map[string]api.ToolParameter{
+		"revision": {
+			Type:        api.String,
+			Description: "The branch (or revision) to switch to, or the name of the branch to create.",
+			Required:    true,
+		},
+		"create": {
+			Type:        api.Boolean,
+			Description: "Whether to create a new branch (starting at the current HEAD) and switch to it.",
+			Default:     false,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		revision, _ := args["revision"].(string)
+		if revision == "" {
+			return "", errors.New("revision is required")
+		}
+		if invalid := invalidArgument(revision); invalid != "" {
+			return invalid, nil
+		}
+		gitArgs := []string{"checkout"}
+		if create, _ := args["create"].(bool); create {
+			gitArgs = append(gitArgs, "-b")
+		}
+		output, err := git(append(gitArgs, revision, "--")...)
+		if err == nil && output == "" {
+			return "Switched to " + revision + ".", nil
+		}
+		return output, err
+	},
+}
+
+var GitBranchCreate = &api.Tool{
+	Name:        "git_branch_create",
+	Description: "Create a new branch in the git repository without switching to it.",
+	Parameters: map[string]api.ToolParameter{
+		"name": {
+			Type:        api.String,
+			Description: "The name of the branch to create.",
+			Required:    true,
+		},
+		"start_point": {
+			Type:        api.String,
+			Description: "The revision the branch starts at. If not provided, the current HEAD is used.",
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		name, _ := args["name"].(string)
+		if name == "" {
+			return "", errors.New("name is required")
+		}
+		startPoint, _ := args["start_point"].(string)
+		if invalid := invalidArgument(name, startPoint); invalid != "" {
+			return invalid, nil
+		}
+		gitArgs := []string{"branch", name}
+		if startPoint != "" {
+			gitArgs = append(gitArgs, startPoint)
+		}
+		output, err := git(gitArgs...)
+		if err == nil && output == "" {
+			return "Branch " + name + " created.", nil
+		}
+		return output, err
+	},
+}
+
+var GitBranchDelete = &api.Tool{
+	Name: "git_branch_delete",
+	Description: "Delete a local branch of the git repository. " +
+		"The branch must be fully merged unless force is set.",
+	Destructive: true,
+	Parameters: map[string]api.ToolParameter{
+		"name": {
+			Type:        api.String,
+			Description: "The name of the branch to delete.",
+			Required:    true,
+		},
+		"force": {
+			Type:        api.Boolean,
+			Description: "Whether to delete the branch even if it's not merged (its commits might be lost).",
+			Default:     false,
+		},
+	},
+	Function: func(args map[string]interface{}) (string, error) {
+		name, _ := args["name"].(string)
+		if name == "" {
+			return "", errors.New("name is required")
+		}
+		if invalid := invalidArgument(name); invalid != "" {
+			return invalid, nil
+		}
+		flag := "--delete"
+		if force, _ := args["force"].(bool); force {
+			flag = "-D"
+		}
+		return git("branch", flag, name)
+	},
+}
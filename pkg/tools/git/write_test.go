@@ -0,0 +1,101 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GitWriteTestSuite struct {
+	repositorySuite
+}
+
+func (s *GitWriteTestSuite) TestGitCommit() {
+	s.Run("Commits the provided paths", func() {
+		s.writeFile("new.txt", "new\n")
+		s.writeFile("other.txt", "other\n")
+		result, err := GitCommit.Function(map[string]interface{}{"message": "Add new file", "paths": []interface{}{"new.txt"}})
+		s.NoError(err)
+		s.Contains(result, "Add new file")
+		s.Equal("Add new file\n", s.git("log", "-1", "--format=%s"))
+		s.Equal("new.txt\n", s.git("show", "--name-only", "--format=", "HEAD"))
+	})
+	s.Run("Commits all the tracked files", func() {
+		s.writeFile("main.go", "package main\n")
+		result, err := GitCommit.Function(map[string]interface{}{"message": "Simplify main", "all": true})
+		s.NoError(err)
+		s.Contains(result, "Simplify main")
+		s.Equal("main.go\n", s.git("show", "--name-only", "--format=", "HEAD"))
+	})
+	s.Run("Returns the git error if there is nothing to commit", func() {
+		result, err := GitCommit.Function(map[string]interface{}{"message": "Nothing"})
+		s.NoError(err)
+		s.True(strings.HasPrefix(result, "git commit failed (exit code 1):\n"), result)
+		s.Contains(result, "nothing added to commit")
+	})
+	s.Run("Returns error for missing message", func() {
+		_, err := GitCommit.Function(map[string]interface{}{})
+		s.EqualError(err, "message is required")
+	})
+}
+
+func (s *GitWriteTestSuite) TestGitCheckout() {
+	s.Run("Creates and switches to a branch", func() {
+		result, err := GitCheckout.Function(map[string]interface{}{"revision": "feature", "create": true})
+		s.NoError(err)
+		s.Equal("Switched to feature.", result)
+		s.Equal("feature\n", s.git("branch", "--show-current"))
+	})
+	s.Run("Switches to an existing branch", func() {
+		_, err := GitCheckout.Function(map[string]interface{}{"revision": "main"})
+		s.NoError(err)
+		s.Equal("main\n", s.git("branch", "--show-current"))
+	})
+	s.Run("Keeps local changes", func() {
+		s.writeFile("README.md", "local change\n")
+		result, err := GitCheckout.Function(map[string]interface{}{"revision": "HEAD~2"})
+		s.NoError(err)
+		s.Contains(result, "git checkout failed")
+		s.Contains(result, "Your local changes to the following files would be overwritten by checkout")
+	})
+	s.Run("Rejects options", func() {
+		result, err := GitCheckout.Function(map[string]interface{}{"revision": "--force"})
+		s.NoError(err)
+		s.Contains(result, "Invalid argument '--force'")
+	})
+}
+
+func (s *GitWriteTestSuite) TestGitBranch() {
+	s.Run("Creates a branch without switching to it", func() {
+		result, err := GitBranchCreate.Function(map[string]interface{}{"name": "feature", "start_point": "HEAD~1"})
+		s.NoError(err)
+		s.Equal("Branch feature created.", result)
+		s.Equal("main\n", s.git("branch", "--show-current"))
+		s.Equal(s.git("rev-parse", "HEAD~1"), s.git("rev-parse", "feature"))
+	})
+	s.Run("Deletes a merged branch", func() {
+		result, err := GitBranchDelete.Function(map[string]interface{}{"name": "feature"})
+		s.NoError(err)
+		s.Contains(result, "Deleted branch feature")
+	})
+	s.Run("Doesn't delete an unmerged branch", func() {
+		s.git("checkout", "-b", "unmerged")
+		s.writeFile("unmerged.txt", "unmerged\n")
+		s.commit("Alice", "2024-04-01T10:00:00", "Unmerged change")
+		s.git("checkout", "main")
+		result, err := GitBranchDelete.Function(map[string]interface{}{"name": "unmerged"})
+		s.NoError(err)
+		s.Contains(result, "git branch failed")
+		s.Contains(result, "not fully merged")
+	})
+	s.Run("Force deletes an unmerged branch", func() {
+		result, err := GitBranchDelete.Function(map[string]interface{}{"name": "unmerged", "force": true})
+		s.NoError(err)
+		s.Contains(result, "Deleted branch unmerged")
+	})
+}
+
+func TestGitWrite(t *testing.T) {
+	suite.Run(t, new(GitWriteTestSuite))
+}
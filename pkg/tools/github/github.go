@@ -2,8 +2,14 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"os/exec"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/v2/list"
 	"github.com/manusa/ai-cli/pkg/api"
@@ -22,46 +28,205 @@ type Provider struct {
 var _ api.ToolsProvider = &Provider{}
 
 const (
-	accessTokenEnvVar               = "GITHUB_PERSONAL_ACCESS_TOKEN"
-	createNewPersonalAccessTokenUrl = "https://github.com/settings/personal-access-tokens/new"
+	accessTokenEnvVar = "GITHUB_PERSONAL_ACCESS_TOKEN"
+	hostEnvVar        = "GITHUB_HOST"
+	defaultHost       = "github.com"
+	defaultMcpUrl     = "https://api.githubcopilot.com/mcp/"
+	localMcpServer    = "github-mcp-server"
+	localMcpImage     = "ghcr.io/github/github-mcp-server"
+	// probeTimeout is the maximum duration of the hosted MCP server reachability check
+	probeTimeout = 3 * time.Second
 )
 
+// defaultToolsets are the GitHub MCP server toolsets enabled if none is configured
+var defaultToolsets = []string{"context", "actions", "issues", "notifications", "pull_requests", "repos", "users"}
+
+// mcpUrlReachableFunc checks if the hosted MCP server is reachable (any HTTP response, the probe is unauthenticated)
+var mcpUrlReachableFunc = func(mcpUrl string) bool {
+	client := &http.Client{Timeout: probeTimeout}
+	resp, err := client.Head(mcpUrl)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return true
+}
+
+// ghAuthTokenFunc returns the token of the gh CLI for the host (if gh is installed and authenticated)
+var ghAuthTokenFunc = func(host string) (string, error) {
+	if !config.CommandExists("gh") {
+		return "", errors.New("gh is not installed")
+	}
+	output, err := exec.Command("gh", "auth", "token", "--hostname", host).Output()
+	if err != nil {
+		return "", fmt.Errorf("gh is not authenticated for %s", host)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 func (p *Provider) Initialize(ctx context.Context) {
 	// TODO: probably move to features.Discover orchestration
 	if cfg := config.GetConfig(ctx); cfg != nil {
 		p.ToolsParameters = cfg.ToolsParameters(p.Attributes().Name())
 	}
 
-	accessToken := p.getAccessToken()
+	host, err := p.getHost()
+	if err != nil {
+		p.IsAvailableReason = err.Error()
+		return
+	}
+
+	accessToken, reason := p.getAccessToken(host)
 	if accessToken == "" {
-		p.IsAvailableReason = fmt.Sprintf("%s is not set", accessTokenEnvVar)
+		p.IsAvailableReason = reason
+		return
+	}
+
+	toolsets := defaultToolsets
+	if len(p.Toolsets) > 0 {
+		toolsets = p.Toolsets
+	}
+	// The GitHub MCP server has no non-destructive mode, and its write tools aren't annotated as non-destructive
+	// (they would be filtered out anyway), so the read-only mode is used if destructive tools are disabled too
+	readOnly := (p.ReadOnly != nil && *p.ReadOnly) || (p.DisableDestructive != nil && *p.DisableDestructive)
+
+	mcpUrl, err := mcpUrl(host)
+	if err != nil || (p.Local != nil && *p.Local) {
+		// The local GitHub MCP server is required (GitHub Enterprise Server or hosted MCP server disallowed)
+		p.McpSettings, err = findLocalMcpServerSettings(accessToken, host, toolsets, readOnly)
+		if err != nil {
+			p.IsAvailableReason = err.Error()
+			return
+		}
+		p.IsAvailableReason = fmt.Sprintf("%s (local %s)", reason, p.McpSettings.Command)
+		p.Available = true
+		return
+	}
+	// Fall back to the local GitHub MCP server if available, the hosted one might be temporarily unreachable.
+	// The hosted MCP server is only probed if there's a fallback, since it's kept otherwise.
+	if localMcpSettings, err := findLocalMcpServerSettings(accessToken, host, toolsets, readOnly); err == nil && !mcpUrlReachableFunc(mcpUrl) {
+		p.McpSettings = localMcpSettings
+		p.IsAvailableReason = fmt.Sprintf("%s (local %s, %s is unreachable)", reason, p.McpSettings.Command, mcpUrl)
+		p.Available = true
 		return
 	}
 
 	headers := map[string]string{
 		"Authorization":  "Bearer " + accessToken,
-		"X-MCP-Toolsets": "context,actions,issues,notifications,pull_requests,repos,users",
+		"X-MCP-Toolsets": strings.Join(toolsets, ","),
 	}
-	if *p.ReadOnly {
+	if readOnly {
 		headers["X-MCP-Readonly"] = "true"
 	}
-	p.IsAvailableReason = fmt.Sprintf("%s is set", accessTokenEnvVar)
+	p.IsAvailableReason = reason
 	p.Available = true
 	p.McpSettings = &api.McpSettings{
 		Type:    api.McpTypeStreamableHttp,
-		Url:     "https://api.githubcopilot.com/mcp/",
+		Url:     mcpUrl,
 		Headers: headers,
 	}
 }
 
-func (p *Provider) getAccessToken() string {
+// findLocalMcpServerSettings returns the settings to run the GitHub MCP server locally over stdio, either with the
+// github-mcp-server binary (preferred) or with a podman container
+func findLocalMcpServerSettings(accessToken, host string, toolsets []string, readOnly bool) (*api.McpSettings, error) {
+	env := []string{accessTokenEnvVar + "=" + accessToken}
+	if host != defaultHost {
+		env = append(env, hostEnvVar+"=https://"+host)
+	}
+	serverArgs := []string{"stdio", "--toolsets=" + strings.Join(toolsets, ",")}
+	if readOnly {
+		serverArgs = append(serverArgs, "--read-only")
+	}
+	if config.CommandExists(localMcpServer) {
+		return &api.McpSettings{
+			Type:    api.McpTypeStdio,
+			Command: localMcpServer,
+			Args:    serverArgs,
+			Env:     env,
+		}, nil
+	}
+	if config.CommandExists("podman") {
+		// Environment variables are passed by name so that their values (the token) aren't exposed in the arguments
+		args := []string{"run", "-i", "--rm"}
+		for _, e := range env {
+			name, _, _ := strings.Cut(e, "=")
+			args = append(args, "-e", name)
+		}
+		return &api.McpSettings{
+			Type:    api.McpTypeStdio,
+			Command: "podman",
+			Args:    append(append(args, localMcpImage), serverArgs...),
+			Env:     env,
+		}, nil
+	}
+	return nil, fmt.Errorf("no suitable MCP settings found for the local GitHub MCP server (%s or podman)", localMcpServer)
+}
+
+// getAccessToken returns the token from the keyring, the GITHUB_PERSONAL_ACCESS_TOKEN environment variable, or the
+// gh CLI, and the reason describing its source (or why there's none)
+func (p *Provider) getAccessToken(host string) (string, string) {
 	if key, err := keyring.GetKey(accessTokenEnvVar); err == nil && len(key) > 0 {
-		return key
+		return key, fmt.Sprintf("%s is set", accessTokenEnvVar)
 	}
-	return os.Getenv(accessTokenEnvVar)
+	if token := os.Getenv(accessTokenEnvVar); token != "" {
+		return token, fmt.Sprintf("%s is set", accessTokenEnvVar)
+	}
+	token, err := ghAuthTokenFunc(host)
+	if err == nil && token != "" {
+		return token, fmt.Sprintf("gh is authenticated for %s", host)
+	}
+	if config.CommandExists("gh") {
+		return "", fmt.Sprintf("%s is not set and gh is not authenticated for %s", accessTokenEnvVar, host)
+	}
+	return "", fmt.Sprintf("%s is not set", accessTokenEnvVar)
+}
+
+// getHost returns the GitHub host from the provider instance URL configuration, the GITHUB_HOST environment
+// variable, or github.com
+func (p *Provider) getHost() (string, error) {
+	instanceUrl := os.Getenv(hostEnvVar)
+	if p.InstanceUrl != nil && *p.InstanceUrl != "" {
+		instanceUrl = *p.InstanceUrl
+	}
+	if instanceUrl == "" {
+		return defaultHost, nil
+	}
+	if !strings.Contains(instanceUrl, "://") {
+		instanceUrl = "https://" + instanceUrl
+	}
+	u, err := url.Parse(instanceUrl)
+	if err != nil || u.Hostname() == "" {
+		return "", fmt.Errorf("invalid GitHub instance URL '%s'", instanceUrl)
+	}
+	return strings.ToLower(u.Host), nil
+}
+
+// mcpUrl returns the URL of the hosted GitHub MCP server for the host.
+// GitHub Enterprise Cloud with data residency (*.ghe.com) has its own endpoint, GitHub Enterprise Server isn't
+// supported by the hosted MCP server (the local one is used instead).
+func mcpUrl(host string) (string, error) {
+	switch {
+	case host == defaultHost:
+		return defaultMcpUrl, nil
+	case strings.HasSuffix(host, ".ghe.com"):
+		return fmt.Sprintf("https://copilot-api.%s/mcp/", host), nil
+	default:
+		return "", fmt.Errorf("the hosted GitHub MCP server doesn't support GitHub Enterprise Server (%s)", host)
+	}
+}
+
+// personalAccessTokenUrl returns the URL of the page to create a personal access token for the host
+func personalAccessTokenUrl(host string) string {
+	return fmt.Sprintf("https://%s/settings/personal-access-tokens/new", host)
 }
 
 func (p *Provider) InstallHelp() error {
+	host, err := p.getHost()
+	if err != nil {
+		return err
+	}
+	createNewPersonalAccessTokenUrl := personalAccessTokenUrl(host)
 	createNewPersonalAccessToken := "Create a new GitHub Personal Access Token"
 	registerExistingPersonalAccessToken := "Register an existing GitHub Personal Access Token"
 	quit := "Terminate GitHub setup"
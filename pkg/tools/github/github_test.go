@@ -1,22 +1,31 @@
 package kubernetes
 
 import (
+	"errors"
+	"net/http"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
 	"github.com/manusa/ai-cli/pkg/config"
 	"github.com/manusa/ai-cli/pkg/features"
 	"github.com/manusa/ai-cli/pkg/inference"
 	"github.com/manusa/ai-cli/pkg/keyring"
 	"github.com/manusa/ai-cli/pkg/policies"
 	"github.com/manusa/ai-cli/pkg/tools"
+	"github.com/manusa/ai-cli/pkg/utils"
 	"github.com/stretchr/testify/suite"
 )
 
 type GithubTestSuite struct {
 	suite.Suite
-	originalEnv []string
+	originalEnv          []string
+	originalGhAuthToken  func(string) (string, error)
+	originalLookPath     func(string) (string, error)
+	originalMcpReachable func(string) bool
+	ghAuthTokenHostnames []string
 }
 
 func (s *GithubTestSuite) SetupTest() {
@@ -27,12 +36,32 @@ func (s *GithubTestSuite) SetupTest() {
 	inference.Clear()
 	tools.Clear()
 	tools.Register(instance)
+	s.originalGhAuthToken = ghAuthTokenFunc
+	s.originalLookPath = config.LookPath
+	s.originalMcpReachable = mcpUrlReachableFunc
+	mcpUrlReachableFunc = func(string) bool { return true }
+	s.ghAuthTokenHostnames = nil
+	ghAuthTokenFunc = func(host string) (string, error) {
+		s.ghAuthTokenHostnames = append(s.ghAuthTokenHostnames, host)
+		return "", errors.New("gh is not installed")
+	}
 }
 
 func (s *GithubTestSuite) TearDownTest() {
+	ghAuthTokenFunc = s.originalGhAuthToken
+	config.LookPath = s.originalLookPath
+	mcpUrlReachableFunc = s.originalMcpReachable
 	test.RestoreEnv(s.originalEnv)
 }
 
+// initialize returns a provider initialized with the parameters (without the default configuration)
+func (s *GithubTestSuite) initialize(parameters api.ToolsParameters) *Provider {
+	p := &Provider{}
+	p.ToolsParameters = parameters
+	p.Initialize(s.T().Context())
+	return p
+}
+
 func (s *GithubTestSuite) TestFeatureAttributes() {
 	s.Run("feature name is github", func() {
 		s.Equal("github", instance.FeatureName)
@@ -123,6 +152,181 @@ func (s *GithubTestSuite) TestInitializeReadOnly() {
 	})
 }
 
+func (s *GithubTestSuite) TestInitializeDisableDestructive() {
+	_ = os.Setenv("GITHUB_PERSONAL_ACCESS_TOKEN", "fake-token")
+	p := s.initialize(api.ToolsParameters{DisableDestructive: utils.Ptr(true)})
+	s.Require().True(p.IsAvailable())
+	s.Run("sets X-MCP-Readonly header", func() {
+		s.Equal("true", p.McpSettings.Headers["X-MCP-Readonly"])
+	})
+}
+
+func (s *GithubTestSuite) TestInitializeToolsets() {
+	_ = os.Setenv("GITHUB_PERSONAL_ACCESS_TOKEN", "fake-token")
+	p := s.initialize(api.ToolsParameters{Toolsets: []string{"repos", "code_security"}})
+	s.Require().True(p.IsAvailable())
+	s.Run("sets configured toolsets in X-MCP-Toolsets header", func() {
+		s.Equal("repos,code_security", p.McpSettings.Headers["X-MCP-Toolsets"])
+	})
+}
+
+func (s *GithubTestSuite) TestInitializeEnterpriseHosts() {
+	_ = os.Setenv("GITHUB_PERSONAL_ACCESS_TOKEN", "fake-token")
+	s.Run("GitHub Enterprise Cloud with data residency uses its MCP endpoint", func() {
+		p := s.initialize(api.ToolsParameters{InstanceUrl: utils.Ptr("https://octocorp.ghe.com")})
+		s.Require().True(p.IsAvailable())
+		s.Equal("https://copilot-api.octocorp.ghe.com/mcp/", p.McpSettings.Url)
+	})
+	s.Run("GITHUB_HOST environment variable is used", func() {
+		_ = os.Setenv("GITHUB_HOST", "octocorp.ghe.com")
+		p := s.initialize(api.ToolsParameters{})
+		s.Require().True(p.IsAvailable())
+		s.Equal("https://copilot-api.octocorp.ghe.com/mcp/", p.McpSettings.Url)
+	})
+	s.Run("GitHub Enterprise Server falls back to the local MCP server", func() {
+		s.lookPath("github-mcp-server")
+		p := s.initialize(api.ToolsParameters{InstanceUrl: utils.Ptr("https://github.example.com")})
+		s.Require().True(p.IsAvailable())
+		s.Equal("github-mcp-server", p.McpSettings.Command)
+		s.Contains(p.McpSettings.Env, "GITHUB_HOST=https://github.example.com")
+	})
+	s.Run("GitHub Enterprise Server is not available without a local MCP server", func() {
+		s.lookPath()
+		p := s.initialize(api.ToolsParameters{InstanceUrl: utils.Ptr("https://github.example.com")})
+		s.False(p.IsAvailable())
+		s.Equal("no suitable MCP settings found for the local GitHub MCP server (github-mcp-server or podman)", p.Reason())
+	})
+	s.Run("personal access token page is on the host", func() {
+		s.Equal("https://octocorp.ghe.com/settings/personal-access-tokens/new", personalAccessTokenUrl("octocorp.ghe.com"))
+	})
+}
+
+func (s *GithubTestSuite) TestInitializeGhAuthToken() {
+	ghAuthTokenFunc = func(host string) (string, error) {
+		s.ghAuthTokenHostnames = append(s.ghAuthTokenHostnames, host)
+		return "gh-token", nil
+	}
+	s.Run("uses the gh CLI token", func() {
+		p := s.initialize(api.ToolsParameters{InstanceUrl: utils.Ptr("octocorp.ghe.com")})
+		s.Require().True(p.IsAvailable())
+		s.Equal("gh is authenticated for octocorp.ghe.com", p.Reason())
+		s.Equal("Bearer gh-token", p.McpSettings.Headers["Authorization"])
+		s.Equal([]string{"octocorp.ghe.com"}, s.ghAuthTokenHostnames)
+	})
+	s.Run("GITHUB_PERSONAL_ACCESS_TOKEN takes precedence", func() {
+		_ = os.Setenv("GITHUB_PERSONAL_ACCESS_TOKEN", "fake-token")
+		p := s.initialize(api.ToolsParameters{})
+		s.Equal("Bearer fake-token", p.McpSettings.Headers["Authorization"])
+	})
+}
+
+func (s *GithubTestSuite) TestInitializeGhNotAuthenticated() {
+	config.LookPath = func(file string) (string, error) { return "/usr/bin/" + file, nil }
+	p := s.initialize(api.ToolsParameters{})
+	s.False(p.IsAvailable())
+	s.Equal("GITHUB_PERSONAL_ACCESS_TOKEN is not set and gh is not authenticated for github.com", p.Reason())
+}
+
+func (s *GithubTestSuite) TestInitializeLocal() {
+	_ = os.Setenv("GITHUB_PERSONAL_ACCESS_TOKEN", "fake-token")
+	s.Run("uses the github-mcp-server binary if available", func() {
+		s.lookPath("github-mcp-server", "podman")
+		p := s.initialize(api.ToolsParameters{Local: utils.Ptr(true), Toolsets: []string{"repos", "issues"}})
+		s.Require().True(p.IsAvailable())
+		s.Equal("GITHUB_PERSONAL_ACCESS_TOKEN is set (local github-mcp-server)", p.Reason())
+		s.Equal(api.McpTypeStdio, p.McpSettings.Type)
+		s.Equal("github-mcp-server", p.McpSettings.Command)
+		s.Equal([]string{"stdio", "--toolsets=repos,issues"}, p.McpSettings.Args)
+		s.Equal([]string{"GITHUB_PERSONAL_ACCESS_TOKEN=fake-token"}, p.McpSettings.Env)
+	})
+	s.Run("uses a podman container if the binary is not available", func() {
+		s.lookPath("podman")
+		p := s.initialize(api.ToolsParameters{Local: utils.Ptr(true), ReadOnly: utils.Ptr(true)})
+		s.Require().True(p.IsAvailable())
+		s.Equal("GITHUB_PERSONAL_ACCESS_TOKEN is set (local podman)", p.Reason())
+		s.Equal("podman", p.McpSettings.Command)
+		s.Equal([]string{
+			"run", "-i", "--rm", "-e", "GITHUB_PERSONAL_ACCESS_TOKEN", "ghcr.io/github/github-mcp-server",
+			"stdio", "--toolsets=context,actions,issues,notifications,pull_requests,repos,users", "--read-only",
+		}, p.McpSettings.Args)
+		s.Equal([]string{"GITHUB_PERSONAL_ACCESS_TOKEN=fake-token"}, p.McpSettings.Env)
+	})
+	s.Run("is not available without binary or podman", func() {
+		s.lookPath()
+		p := s.initialize(api.ToolsParameters{Local: utils.Ptr(true)})
+		s.False(p.IsAvailable())
+		s.Equal("no suitable MCP settings found for the local GitHub MCP server (github-mcp-server or podman)", p.Reason())
+	})
+	s.Run("local tools policy enforces the local MCP server", func() {
+		s.lookPath("github-mcp-server")
+		cfg := config.New()
+		cfg.Enforce(test.Must(policies.ReadToml(`
+[tools]
+local = true
+`)))
+		p := &Provider{}
+		p.Initialize(config.WithConfig(s.T().Context(), cfg))
+		s.Require().True(p.IsAvailable())
+		s.Equal("github-mcp-server", p.McpSettings.Command)
+		s.Empty(p.McpSettings.Url)
+	})
+}
+
+func (s *GithubTestSuite) TestInitializeHostedUnreachable() {
+	_ = os.Setenv("GITHUB_PERSONAL_ACCESS_TOKEN", "fake-token")
+	var probedUrls []string
+	mcpUrlReachableFunc = func(mcpUrl string) bool {
+		probedUrls = append(probedUrls, mcpUrl)
+		return false
+	}
+	s.Run("falls back to the local MCP server", func() {
+		s.lookPath("github-mcp-server")
+		p := s.initialize(api.ToolsParameters{})
+		s.Require().True(p.IsAvailable())
+		s.Equal("GITHUB_PERSONAL_ACCESS_TOKEN is set (local github-mcp-server, https://api.githubcopilot.com/mcp/ is unreachable)", p.Reason())
+		s.Equal(api.McpTypeStdio, p.McpSettings.Type)
+		s.Equal("github-mcp-server", p.McpSettings.Command)
+		s.Equal([]string{"https://api.githubcopilot.com/mcp/"}, probedUrls)
+	})
+	s.Run("keeps the hosted MCP server without probing it without a local MCP server", func() {
+		probedUrls = nil
+		s.lookPath()
+		p := s.initialize(api.ToolsParameters{})
+		s.Require().True(p.IsAvailable())
+		s.Equal("GITHUB_PERSONAL_ACCESS_TOKEN is set", p.Reason())
+		s.Equal("https://api.githubcopilot.com/mcp/", p.McpSettings.Url)
+		s.Empty(probedUrls)
+	})
+}
+
+func (s *GithubTestSuite) TestMcpUrlReachable() {
+	mockServer := test.NewMockServer()
+	mockServer.Handle(func(w http.ResponseWriter, req *http.Request) (handled bool) {
+		if req.Method == http.MethodHead && req.URL.Path == "/mcp/" {
+			w.WriteHeader(http.StatusUnauthorized)
+			handled = true
+		}
+		return
+	})
+	s.Run("any HTTP response is reachable", func() {
+		s.True(s.originalMcpReachable(mockServer.URL() + "/mcp/"))
+	})
+	s.Run("connection errors are unreachable", func() {
+		mockServer.Close()
+		s.False(s.originalMcpReachable(mockServer.URL() + "/mcp/"))
+	})
+}
+
+// lookPath stubs config.LookPath so that only the provided commands are found
+func (s *GithubTestSuite) lookPath(commands ...string) {
+	config.LookPath = func(file string) (string, error) {
+		if slices.Contains(commands, file) {
+			return "/usr/bin/" + file, nil
+		}
+		return "", errors.New("not found")
+	}
+}
+
 func TestGithub(t *testing.T) {
 	suite.Run(t, new(GithubTestSuite))
 }
@@ -0,0 +1,187 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/list"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/keyring"
+	"github.com/manusa/ai-cli/pkg/system"
+	"github.com/manusa/ai-cli/pkg/tools"
+	"github.com/manusa/ai-cli/pkg/ui/components/password_input"
+	"github.com/manusa/ai-cli/pkg/ui/components/selector"
+)
+
+type Provider struct {
+	api.BasicToolsProvider
+}
+
+var _ api.ToolsProvider = &Provider{}
+
+const (
+	accessTokenEnvVar  = "GITLAB_PERSONAL_ACCESS_TOKEN"
+	instanceUrlEnvVar  = "GITLAB_URL"
+	defaultInstanceUrl = "https://gitlab.com"
+)
+
+var (
+	supportedMcpSettings = map[string]api.McpSettings{
+		"npx": {
+			Type:    api.McpTypeStdio,
+			Command: "npx",
+			Args: []string{
+				"-y",
+				"@zereight/mcp-gitlab",
+			},
+		},
+	}
+)
+
+func (p *Provider) Initialize(ctx context.Context) {
+	// TODO: probably move to features.Discover orchestration
+	if cfg := config.GetConfig(ctx); cfg != nil {
+		p.ToolsParameters = cfg.ToolsParameters(p.Attributes().Name())
+	}
+
+	accessToken := p.getAccessToken()
+	if accessToken == "" {
+		p.IsAvailableReason = fmt.Sprintf("%s is not set", accessTokenEnvVar)
+		return
+	}
+
+	instanceUrl, err := p.getInstanceUrl()
+	if err != nil {
+		p.IsAvailableReason = err.Error()
+		return
+	}
+
+	p.McpSettings, err = p.findBestMcpServerSettings(accessToken, instanceUrl)
+	if err != nil {
+		p.IsAvailableReason = err.Error()
+		return
+	}
+	p.IsAvailableReason = fmt.Sprintf("%s is set for %s", accessTokenEnvVar, instanceUrl)
+	p.Available = true
+}
+
+func (p *Provider) getAccessToken() string {
+	if key, err := keyring.GetKey(accessTokenEnvVar); err == nil && len(key) > 0 {
+		return key
+	}
+	return os.Getenv(accessTokenEnvVar)
+}
+
+// getInstanceUrl returns the URL of the GitLab instance from the provider configuration, the GITLAB_URL environment
+// variable, or gitlab.com
+func (p *Provider) getInstanceUrl() (string, error) {
+	instanceUrl := os.Getenv(instanceUrlEnvVar)
+	if p.InstanceUrl != nil && *p.InstanceUrl != "" {
+		instanceUrl = *p.InstanceUrl
+	}
+	if instanceUrl == "" {
+		return defaultInstanceUrl, nil
+	}
+	u, err := url.Parse(instanceUrl)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("invalid GitLab instance URL '%s'", instanceUrl)
+	}
+	return strings.TrimSuffix(u.String(), "/"), nil
+}
+
+// findBestMcpServerSettings returns the settings of the GitLab MCP server, in read-only mode for read-only toolsets
+func (p *Provider) findBestMcpServerSettings(accessToken, instanceUrl string) (*api.McpSettings, error) {
+	for command, settings := range supportedMcpSettings {
+		if config.CommandExists(command) {
+			settings.Env = []string{
+				fmt.Sprintf("%s=%s", accessTokenEnvVar, accessToken),
+				fmt.Sprintf("GITLAB_API_URL=%s/api/v4", instanceUrl),
+			}
+			if p.ReadOnly != nil && *p.ReadOnly {
+				settings.Env = append(settings.Env, "GITLAB_READ_ONLY_MODE=true")
+			}
+			return &settings, nil
+		}
+	}
+	return nil, errors.New("no suitable MCP settings found for the GitLab MCP server")
+}
+
+// createNewPersonalAccessTokenUrl returns the URL of the instance page to create a personal access token with the
+// scopes required by the MCP server (read_api is enough for read-only toolsets)
+func (p *Provider) createNewPersonalAccessTokenUrl(instanceUrl string) string {
+	scopes := "api"
+	if p.ReadOnly != nil && *p.ReadOnly {
+		scopes = "read_api"
+	}
+	return fmt.Sprintf("%s/-/user_settings/personal_access_tokens?name=ai-cli&scopes=%s", instanceUrl, scopes)
+}
+
+func (p *Provider) InstallHelp() error {
+	instanceUrl, err := p.getInstanceUrl()
+	if err != nil {
+		return err
+	}
+	createNewPersonalAccessToken := fmt.Sprintf("Create a new GitLab Personal Access Token (%s)", instanceUrl)
+	registerExistingPersonalAccessToken := "Register an existing GitLab Personal Access Token"
+	quit := "Terminate GitLab setup"
+	choices := []list.Item{
+		selector.Item(createNewPersonalAccessToken),
+		selector.Item(registerExistingPersonalAccessToken),
+		selector.Item(quit),
+	}
+	for {
+		choice, err := selector.Select("Please select a step:", choices)
+		if err != nil {
+			return err
+		}
+		switch choice {
+		case createNewPersonalAccessToken:
+			tokenUrl := p.createNewPersonalAccessTokenUrl(instanceUrl)
+			fmt.Printf("Opening browser to create a new personal access token...\nYou can also access the page at %s\n", tokenUrl)
+			if instanceUrl == defaultInstanceUrl {
+				fmt.Printf("For a self-managed GitLab instance, set the %s environment variable or the instance-url tools configuration\n", instanceUrlEnvVar)
+			}
+			err = system.OpenBrowser(tokenUrl)
+			if err != nil {
+				return err
+			}
+		case registerExistingPersonalAccessToken:
+			fmt.Printf("Paste your token below:\n")
+			apiKey, err := password_input.Prompt()
+			if err != nil {
+				return err
+			}
+			err = keyring.SetKey(accessTokenEnvVar, apiKey)
+			if err != nil {
+				return err
+			}
+		case quit:
+			return nil
+		}
+	}
+}
+
+func (p *Provider) Clear(ctx context.Context) (done bool, err error) {
+	return keyring.DeleteKey(accessTokenEnvVar)
+}
+
+var instance = &Provider{
+	BasicToolsProvider: api.BasicToolsProvider{
+		BasicToolsAttributes: api.BasicToolsAttributes{
+			BasicFeatureAttributes: api.BasicFeatureAttributes{
+				FeatureName:        "gitlab",
+				FeatureDescription: "Provides access to GitLab (gitlab.com or self-managed instances). Provides the ability to read projects and code files, manage issues and merge requests, and inspect pipelines.",
+				SupportsSetupAttr:  true,
+			},
+		},
+	},
+}
+
+func init() {
+	tools.Register(instance)
+}
@@ -0,0 +1,146 @@
+package gitlab
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/features"
+	"github.com/manusa/ai-cli/pkg/inference"
+	"github.com/manusa/ai-cli/pkg/keyring"
+	"github.com/manusa/ai-cli/pkg/policies"
+	"github.com/manusa/ai-cli/pkg/tools"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type GitlabTestSuite struct {
+	suite.Suite
+	originalEnv      []string
+	originalLookPath func(string) (string, error)
+}
+
+func (s *GitlabTestSuite) SetupTest() {
+	keyring.MockInit()
+	instance.Available = false
+	s.originalEnv = os.Environ()
+	os.Clearenv()
+	inference.Clear()
+	tools.Clear()
+	tools.Register(instance)
+	s.originalLookPath = config.LookPath
+	config.LookPath = func(file string) (string, error) {
+		if file == "npx" {
+			return "/path/to/npx", nil
+		}
+		return "", fmt.Errorf("file not found: %s", file)
+	}
+}
+
+func (s *GitlabTestSuite) TearDownTest() {
+	config.LookPath = s.originalLookPath
+	test.RestoreEnv(s.originalEnv)
+}
+
+func (s *GitlabTestSuite) TestFeatureAttributes() {
+	s.Run("feature name is gitlab", func() {
+		s.Equal("gitlab", instance.FeatureName)
+	})
+	s.Run("supports setup", func() {
+		s.True(instance.SupportsSetup())
+	})
+}
+
+func (s *GitlabTestSuite) TestInitializeNoAccessToken() {
+	feats := features.Discover(config.WithConfig(s.T().Context(), config.New()))
+	s.Require().Empty(feats.Tools)
+	s.Require().Len(feats.ToolsNotAvailable, 1)
+	s.Run("when GITLAB_PERSONAL_ACCESS_TOKEN is not set, shows reason", func() {
+		s.Equal("GITLAB_PERSONAL_ACCESS_TOKEN is not set", feats.ToolsNotAvailable[0].Reason())
+	})
+}
+
+func (s *GitlabTestSuite) TestInitializeNoMcpServer() {
+	_ = os.Setenv("GITLAB_PERSONAL_ACCESS_TOKEN", "fake-token")
+	config.LookPath = func(file string) (string, error) { return "", fmt.Errorf("file not found: %s", file) }
+	feats := features.Discover(config.WithConfig(s.T().Context(), config.New()))
+	s.Require().Len(feats.ToolsNotAvailable, 1)
+	s.Equal("no suitable MCP settings found for the GitLab MCP server", feats.ToolsNotAvailable[0].Reason())
+}
+
+func (s *GitlabTestSuite) TestInitialize() {
+	_ = os.Setenv("GITLAB_PERSONAL_ACCESS_TOKEN", "fake-token")
+	feats := features.Discover(config.WithConfig(s.T().Context(), config.New()))
+	s.Require().Len(feats.Tools, 1)
+	s.Run("when GITLAB_PERSONAL_ACCESS_TOKEN is set, shows reason with the instance", func() {
+		s.Equal("GITLAB_PERSONAL_ACCESS_TOKEN is set for https://gitlab.com", feats.Tools[0].Reason())
+	})
+	s.Run("sets MCP settings", func() {
+		mcpSettings := feats.Tools[0].GetMcpSettings()
+		s.Require().NotNil(mcpSettings, "McpSettings should be set")
+		s.Equal(api.McpTypeStdio, mcpSettings.Type)
+		s.Equal("npx", mcpSettings.Command)
+		s.Equal([]string{"-y", "@zereight/mcp-gitlab"}, mcpSettings.Args)
+		s.Equal([]string{"GITLAB_PERSONAL_ACCESS_TOKEN=fake-token", "GITLAB_API_URL=https://gitlab.com/api/v4"}, mcpSettings.Env)
+	})
+}
+
+func (s *GitlabTestSuite) TestInitializeSelfManaged() {
+	_ = os.Setenv("GITLAB_PERSONAL_ACCESS_TOKEN", "fake-token")
+	s.Run("uses GITLAB_URL", func() {
+		_ = os.Setenv("GITLAB_URL", "https://gitlab.example.com/")
+		feats := features.Discover(config.WithConfig(s.T().Context(), config.New()))
+		s.Require().Len(feats.Tools, 1)
+		s.Contains(feats.Tools[0].GetMcpSettings().Env, "GITLAB_API_URL=https://gitlab.example.com/api/v4")
+	})
+	s.Run("instance-url configuration takes precedence", func() {
+		_ = os.Setenv("GITLAB_URL", "https://gitlab.example.com")
+		p := &Provider{}
+		p.InstanceUrl = utils.Ptr("https://git.internal.example.com/gitlab")
+		instanceUrl, err := p.getInstanceUrl()
+		s.NoError(err)
+		s.Equal("https://git.internal.example.com/gitlab", instanceUrl)
+	})
+	s.Run("invalid instance URL", func() {
+		instance.Available = false
+		_ = os.Setenv("GITLAB_URL", "gitlab.example.com")
+		feats := features.Discover(config.WithConfig(s.T().Context(), config.New()))
+		s.Require().Len(feats.ToolsNotAvailable, 1)
+		s.Equal("invalid GitLab instance URL 'gitlab.example.com'", feats.ToolsNotAvailable[0].Reason())
+	})
+}
+
+func (s *GitlabTestSuite) TestInitializeReadOnly() {
+	_ = os.Setenv("GITLAB_PERSONAL_ACCESS_TOKEN", "fake-token")
+	p := test.Must(policies.ReadToml(`
+		[tools]
+		read-only = true
+	`))
+	cfg := config.New()
+	cfg.Enforce(p)
+	feats := features.Discover(config.WithConfig(s.T().Context(), cfg))
+	s.Require().Len(feats.Tools, 1)
+	s.Run("sets GITLAB_READ_ONLY_MODE", func() {
+		s.Contains(feats.Tools[0].GetMcpSettings().Env, "GITLAB_READ_ONLY_MODE=true")
+	})
+}
+
+func (s *GitlabTestSuite) TestCreateNewPersonalAccessTokenUrl() {
+	s.Run("requests the api scope", func() {
+		s.Equal("https://gitlab.example.com/-/user_settings/personal_access_tokens?name=ai-cli&scopes=api",
+			(&Provider{}).createNewPersonalAccessTokenUrl("https://gitlab.example.com"))
+	})
+	s.Run("requests the read_api scope for read-only toolsets", func() {
+		p := &Provider{}
+		p.ReadOnly = utils.Ptr(true)
+		s.Equal("https://gitlab.com/-/user_settings/personal_access_tokens?name=ai-cli&scopes=read_api",
+			p.createNewPersonalAccessTokenUrl("https://gitlab.com"))
+	})
+}
+
+func TestGitlab(t *testing.T) {
+	suite.Run(t, new(GitlabTestSuite))
+}
@@ -0,0 +1,208 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	md "github.com/JohannesKaufmann/html-to-markdown"
+	"github.com/JohannesKaufmann/html-to-markdown/plugin"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/charmbracelet/log"
+	"github.com/manusa/ai-cli/pkg/api"
+)
+
+const (
+	// requestTimeout is the maximum duration of a request (including redirects and reading the response body)
+	requestTimeout = 30 * time.Second
+	// maxResponseSize is the maximum number of bytes read from a response body (the rest is discarded)
+	maxResponseSize = 5 * 1024 * 1024
+	// maxRedirects is the maximum number of redirects followed by a request
+	maxRedirects = 10
+	userAgent    = "ai-cli"
+)
+
+// textMediaTypes are the non text/* media types returned as text
+var textMediaTypes = []string{"application/json", "application/xml", "application/javascript", "application/x-yaml",
+	"application/yaml", "application/x-www-form-urlencoded"}
+
+// checkURL returns an access denied api.ToolResultError if the URL isn't allowed by the toolset parameters
+func (p *Provider) checkURL(u *url.URL) error {
+	host := strings.ToLower(u.Hostname())
+	var err error
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		err = api.NewDeniedError("Access", fmt.Sprintf("unsupported scheme '%s', only http and https URLs are allowed", u.Scheme))
+	case matchDomain(p.DeniedDomains, host) != "":
+		err = api.NewDeniedError("Access", fmt.Sprintf("the domain %s matches the denied pattern '%s'", host, matchDomain(p.DeniedDomains, host)))
+	case len(p.AllowedDomains) > 0 && matchDomain(p.AllowedDomains, host) == "":
+		err = api.NewDeniedError("Access", fmt.Sprintf("the domain %s doesn't match any of the allowed patterns (%s)", host, strings.Join(p.AllowedDomains, ", ")))
+	}
+	if err != nil {
+		log.Warn("http access denied", "url", u.String(), "reason", err.Error())
+	}
+	return err
+}
+
+// matchDomain returns the first glob pattern matching the host (e.g. *.example.com matches docs.example.com)
+func matchDomain(patterns []string, host string) string {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ToLower(pattern), host); matched {
+			return pattern
+		}
+	}
+	return ""
+}
+
+func proxyURL(proxy string) (*url.URL, error) {
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" || !slices.Contains([]string{"http", "https", "socks5"}, u.Scheme) {
+		return nil, fmt.Errorf("invalid proxy URL '%s'", proxy)
+	}
+	return u, nil
+}
+
+// client returns an HTTP client using the configured proxy (or the proxy environment variables) that checks the
+// redirect URLs against the toolset parameters
+func (p *Provider) client() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if p.Proxy != nil && *p.Proxy != "" {
+		proxy, err := proxyURL(*p.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return p.checkURL(req.URL)
+		},
+	}, nil
+}
+
+// do performs the request and returns the response formatted for the model.
+// Invalid URLs and failed requests are reported in the result, denied URLs are returned as api.ToolResultError.
+func (p *Provider) do(method, rawURL string, headers http.Header, body string, raw bool) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return fmt.Sprintf("Invalid URL '%s', provide an absolute http or https URL.", rawURL), nil
+	}
+	if err = p.checkURL(u); err != nil {
+		return "", err
+	}
+	client, err := p.client()
+	if err != nil {
+		return "", err
+	}
+	var requestBody io.Reader
+	if body != "" {
+		requestBody = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, u.String(), requestBody)
+	if err != nil {
+		return "", err
+	}
+	if headers != nil {
+		req.Header = headers
+	}
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "text/html,application/xhtml+xml,application/json,text/plain;q=0.9,*/*;q=0.8")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.As(err, new(*api.ToolResultError)) {
+			return "", err // Redirect denied by checkURL
+		}
+		return fmt.Sprintf("The request failed: %s", err), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return fmt.Sprintf("Reading the response failed: %s", err), nil
+	}
+	truncated := len(data) > maxResponseSize
+	if truncated {
+		data = data[:maxResponseSize]
+	}
+	return formatResponse(resp, data, truncated, raw), nil
+}
+
+// formatResponse returns the status line, the relevant headers and the body (HTML converted to markdown)
+func formatResponse(resp *http.Response, data []byte, truncated bool, raw bool) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("%s %s\n", resp.Proto, resp.Status))
+	if resp.Request.Method == http.MethodHead {
+		_ = resp.Header.Write(&sb)
+		return sb.String()
+	}
+	if resp.Request.Response != nil {
+		// The request was redirected
+		sb.WriteString(fmt.Sprintf("URL: %s\n", resp.Request.URL))
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if contentType != "" {
+		sb.WriteString(fmt.Sprintf("Content-Type: %s\n", contentType))
+	}
+	sb.WriteString("\n")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case len(data) == 0:
+		sb.WriteString("[Empty body]\n")
+	case !raw && (mediaType == "text/html" || mediaType == "application/xhtml+xml"):
+		sb.WriteString(toMarkdown(resp.Request.URL, string(data)))
+	case isText(mediaType, data):
+		sb.Write(data)
+	default:
+		sb.WriteString(fmt.Sprintf("[Binary content (%s, %d bytes) not shown]\n", mediaType, len(data)))
+	}
+	if truncated {
+		sb.WriteString(fmt.Sprintf("\n[Response truncated: the body exceeds %d bytes]\n", maxResponseSize))
+	}
+	return sb.String()
+}
+
+// toMarkdown converts the HTML to markdown (scripts and styles are removed, links are made absolute).
+// The HTML is returned as is if the conversion fails.
+func toMarkdown(base *url.URL, html string) string {
+	converter := md.NewConverter(base.Host, true, &md.Options{
+		GetAbsoluteURL: func(_ *goquery.Selection, rawURL string, _ string) string {
+			if u, err := base.Parse(strings.TrimSpace(rawURL)); err == nil {
+				return u.String()
+			}
+			return rawURL
+		},
+	})
+	converter.Use(plugin.GitHubFlavored())
+	converter.Remove("noscript", "iframe", "svg", "form")
+	markdown, err := converter.ConvertString(html)
+	if err != nil {
+		return html
+	}
+	return markdown + "\n"
+}
+
+// isText returns true for textual media types or content that looks like text
+func isText(mediaType string, data []byte) bool {
+	if strings.HasPrefix(mediaType, "text/") || slices.Contains(textMediaTypes, mediaType) ||
+		strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") {
+		return true
+	}
+	return utf8.Valid(data) && !bytes.ContainsRune(data, 0)
+}
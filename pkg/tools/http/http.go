@@ -0,0 +1,159 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/tools"
+)
+
+type Provider struct {
+	api.BasicToolsProvider
+}
+
+var _ api.ToolsProvider = &Provider{}
+
+func (p *Provider) Initialize(ctx context.Context) {
+	if cfg := config.GetConfig(ctx); cfg != nil {
+		p.ToolsParameters = cfg.ToolsParameters(p.Attributes().Name())
+	}
+	if p.Proxy != nil && *p.Proxy != "" {
+		if _, err := proxyURL(*p.Proxy); err != nil {
+			p.IsAvailableReason = err.Error()
+			return
+		}
+	}
+	p.Available = true
+	p.IsAvailableReason = "HTTP requests can be performed"
+}
+
+// GetTools returns the http tools, http_request is hidden for read-only toolsets or if destructive tools are disabled
+// since it can modify remote resources.
+func (p *Provider) GetTools(_ context.Context) []*api.Tool {
+	httpTools := []*api.Tool{p.httpGet()}
+	if (p.ReadOnly != nil && *p.ReadOnly) || (p.DisableDestructive != nil && *p.DisableDestructive) {
+		return httpTools
+	}
+	return append(httpTools, p.httpRequest())
+}
+
+func (p *Provider) httpGet() *api.Tool {
+	return &api.Tool{
+		Name: "http_get",
+		Description: "Fetch a URL with an HTTP GET request and return the response status, content type and body. " +
+			"HTML pages are converted to markdown (unless raw is set), text and JSON responses are returned as is. " +
+			p.domainsDescription(),
+		ReadOnly: true,
+		Parameters: map[string]api.ToolParameter{
+			"url": {
+				Type:        api.String,
+				Description: "The URL to fetch (http or https).",
+				Required:    true,
+			},
+			"raw": {
+				Type:        api.Boolean,
+				Description: "Whether to return HTML pages as is instead of converting them to markdown.",
+				Default:     false,
+			},
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			url, _ := args["url"].(string)
+			if url == "" {
+				return "", errors.New("url is required")
+			}
+			raw, _ := args["raw"].(bool)
+			return p.do(http.MethodGet, url, nil, "", raw)
+		},
+	}
+}
+
+func (p *Provider) httpRequest() *api.Tool {
+	return &api.Tool{
+		Name: "http_request",
+		Description: "Perform an HTTP request (e.g. to call a REST API) and return the response status, content type and body. " +
+			"HTML pages are converted to markdown (unless raw is set), text and JSON responses are returned as is. " +
+			"Use http_get to fetch a page or a resource. " +
+			p.domainsDescription(),
+		Destructive: true,
+		Parameters: map[string]api.ToolParameter{
+			"method": {
+				Type:        api.String,
+				Description: "The HTTP method.",
+				Required:    true,
+				Enum: []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+					http.MethodDelete, http.MethodOptions},
+			},
+			"url": {
+				Type:        api.String,
+				Description: "The URL of the request (http or https).",
+				Required:    true,
+			},
+			"headers": {
+				Type:        api.Array,
+				Description: "The request headers in the Name: value format (e.g. Content-Type: application/json).",
+				Items:       &api.ToolParameter{Type: api.String},
+			},
+			"body": {
+				Type:        api.String,
+				Description: "The request body.",
+			},
+			"raw": {
+				Type:        api.Boolean,
+				Description: "Whether to return HTML pages as is instead of converting them to markdown.",
+				Default:     false,
+			},
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			method, _ := args["method"].(string)
+			url, _ := args["url"].(string)
+			if method == "" || url == "" {
+				return "", errors.New("method and url are required")
+			}
+			headers := http.Header{}
+			if h, ok := args["headers"].([]interface{}); ok {
+				for _, header := range h {
+					name, value, found := strings.Cut(fmt.Sprint(header), ":")
+					if !found || strings.TrimSpace(name) == "" {
+						return fmt.Sprintf("Invalid header '%v': headers must use the Name: value format.", header), nil
+					}
+					headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+				}
+			}
+			body, _ := args["body"].(string)
+			raw, _ := args["raw"].(bool)
+			return p.do(strings.ToUpper(method), url, headers, body, raw)
+		},
+	}
+}
+
+// domainsDescription describes the domains the tools can access (if restricted)
+func (p *Provider) domainsDescription() string {
+	description := ""
+	if len(p.AllowedDomains) > 0 {
+		description += fmt.Sprintf("Only these domains can be accessed: %s. ", strings.Join(p.AllowedDomains, ", "))
+	}
+	if len(p.DeniedDomains) > 0 {
+		description += fmt.Sprintf("These domains can't be accessed: %s. ", strings.Join(p.DeniedDomains, ", "))
+	}
+	return strings.TrimSpace(description)
+}
+
+var instance = &Provider{
+	api.BasicToolsProvider{
+		BasicToolsAttributes: api.BasicToolsAttributes{
+			BasicFeatureAttributes: api.BasicFeatureAttributes{
+				FeatureName:        "http",
+				FeatureDescription: "Provides lightweight web access, allowing fetching web pages (converted to markdown) and calling HTTP APIs.",
+			},
+		},
+	},
+}
+
+func init() {
+	tools.Register(instance)
+}
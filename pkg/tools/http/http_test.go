@@ -0,0 +1,244 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type HttpTestSuite struct {
+	suite.Suite
+	mockServer *test.MockServer
+}
+
+func (s *HttpTestSuite) SetupTest() {
+	s.mockServer = test.NewMockServer()
+	s.mockServer.Handle(func(w http.ResponseWriter, r *http.Request) bool {
+		switch r.URL.Path {
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(`<html><head><title>Docs</title><style>body{color:red}</style><script>alert("x")</script></head>` +
+				`<body><h1>Getting started</h1><p>Read the <a href="/guide">guide</a> first.</p>` +
+				`<table><tr><th>Name</th><th>Value</th></tr><tr><td>port</td><td>8080</td></tr></table></body></html>`))
+		case "/api":
+			test.WriteObject(w, map[string]interface{}{"status": "ok", "items": []int{1, 2}})
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(r.Method + " " + r.Header.Get("Authorization") + " " + r.Header.Get("User-Agent") + " " + string(body)))
+		case "/redirect":
+			http.Redirect(w, r, "/api", http.StatusFound)
+		case "/redirect-external":
+			http.Redirect(w, r, "http://denied.example.com/", http.StatusFound)
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte{0x89, 'P', 'N', 'G', 0x00, 0x01, 0xff})
+		case "/large":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(strings.Repeat("a", maxResponseSize+10)))
+		default:
+			return false
+		}
+		return true
+	})
+}
+
+func (s *HttpTestSuite) TearDownTest() {
+	s.mockServer.Close()
+}
+
+func (s *HttpTestSuite) tool(parameters api.ToolsParameters, name string) *api.Tool {
+	p := &Provider{}
+	p.ToolsParameters = parameters
+	for _, t := range p.GetTools(s.T().Context()) {
+		if t.Name == name {
+			return t
+		}
+	}
+	s.FailNow("tool not found", name)
+	return nil
+}
+
+func (s *HttpTestSuite) TestGetTools() {
+	toolNames := func(parameters api.ToolsParameters) []string {
+		p := &Provider{}
+		p.ToolsParameters = parameters
+		var names []string
+		for _, t := range p.GetTools(s.T().Context()) {
+			names = append(names, t.Name)
+		}
+		return names
+	}
+	s.Run("All tools by default", func() {
+		s.Equal([]string{"http_get", "http_request"}, toolNames(api.ToolsParameters{}))
+	})
+	s.Run("Read-only hides http_request", func() {
+		s.Equal([]string{"http_get"}, toolNames(api.ToolsParameters{ReadOnly: utils.Ptr(true)}))
+	})
+	s.Run("Disable destructive hides http_request", func() {
+		s.Equal([]string{"http_get"}, toolNames(api.ToolsParameters{DisableDestructive: utils.Ptr(true)}))
+	})
+	s.Run("Describes the domain restrictions", func() {
+		tool := s.tool(api.ToolsParameters{AllowedDomains: []string{"*.example.com"}, DeniedDomains: []string{"internal.example.com"}}, "http_get")
+		s.Contains(tool.Description, "Only these domains can be accessed: *.example.com. These domains can't be accessed: internal.example.com.")
+	})
+}
+
+func (s *HttpTestSuite) TestHttpGet() {
+	httpGet := s.tool(api.ToolsParameters{}, "http_get")
+	s.Run("Converts HTML to markdown", func() {
+		result, err := httpGet.Function(map[string]interface{}{"url": s.mockServer.URL() + "/page"})
+		s.NoError(err)
+		s.True(strings.HasPrefix(result, "HTTP/1.1 200 OK\nContent-Type: text/html; charset=utf-8\n\n"), result)
+		s.Contains(result, "# Getting started")
+		s.Contains(result, "Read the [guide]("+s.mockServer.URL()+"/guide) first.")
+		s.Contains(result, "| Name | Value |")
+		s.NotContains(result, "alert")
+		s.NotContains(result, "color:red")
+	})
+	s.Run("Returns raw HTML", func() {
+		result, err := httpGet.Function(map[string]interface{}{"url": s.mockServer.URL() + "/page", "raw": true})
+		s.NoError(err)
+		s.Contains(result, "<h1>Getting started</h1>")
+	})
+	s.Run("Returns JSON as is", func() {
+		result, err := httpGet.Function(map[string]interface{}{"url": s.mockServer.URL() + "/api"})
+		s.NoError(err)
+		s.Equal("HTTP/1.1 200 OK\nContent-Type: application/json\n\n{\"items\":[1,2],\"status\":\"ok\"}\n", result)
+	})
+	s.Run("Follows redirects", func() {
+		result, err := httpGet.Function(map[string]interface{}{"url": s.mockServer.URL() + "/redirect"})
+		s.NoError(err)
+		s.Contains(result, "URL: "+s.mockServer.URL()+"/api\n")
+		s.Contains(result, `"status":"ok"`)
+	})
+	s.Run("Returns error statuses", func() {
+		result, err := httpGet.Function(map[string]interface{}{"url": s.mockServer.URL() + "/missing"})
+		s.NoError(err)
+		s.Contains(result, "HTTP/1.1 404 Not Found\n")
+	})
+	s.Run("Doesn't return binary content", func() {
+		result, err := httpGet.Function(map[string]interface{}{"url": s.mockServer.URL() + "/image"})
+		s.NoError(err)
+		s.Contains(result, "[Binary content (image/png, 7 bytes) not shown]")
+	})
+	s.Run("Truncates large responses", func() {
+		result, err := httpGet.Function(map[string]interface{}{"url": s.mockServer.URL() + "/large"})
+		s.NoError(err)
+		s.Contains(result, "[Response truncated: the body exceeds 5242880 bytes]")
+		s.Less(len(result), maxResponseSize+200)
+	})
+	s.Run("Returns invalid URLs to the model", func() {
+		result, err := httpGet.Function(map[string]interface{}{"url": "example.com/page"})
+		s.NoError(err)
+		s.Equal("Invalid URL 'example.com/page', provide an absolute http or https URL.", result)
+	})
+	s.Run("Returns failed requests to the model", func() {
+		result, err := httpGet.Function(map[string]interface{}{"url": "http://localhost:0/"})
+		s.NoError(err)
+		s.True(strings.HasPrefix(result, "The request failed: "), result)
+	})
+	s.Run("Returns error for missing url", func() {
+		_, err := httpGet.Function(map[string]interface{}{})
+		s.EqualError(err, "url is required")
+	})
+}
+
+func (s *HttpTestSuite) TestHttpRequest() {
+	httpRequest := s.tool(api.ToolsParameters{}, "http_request")
+	s.Run("Sends the method, headers and body", func() {
+		result, err := httpRequest.Function(map[string]interface{}{
+			"method":  "post",
+			"url":     s.mockServer.URL() + "/echo",
+			"headers": []interface{}{"Authorization: Bearer token", "User-Agent: custom"},
+			"body":    `{"name":"value"}`,
+		})
+		s.NoError(err)
+		s.True(strings.HasSuffix(result, "\n\nPOST Bearer token custom {\"name\":\"value\"}"), result)
+	})
+	s.Run("Sets the default user agent", func() {
+		result, err := httpRequest.Function(map[string]interface{}{"method": "DELETE", "url": s.mockServer.URL() + "/echo"})
+		s.NoError(err)
+		s.True(strings.HasSuffix(result, "\n\nDELETE  ai-cli "), result)
+	})
+	s.Run("Returns the headers of HEAD requests", func() {
+		result, err := httpRequest.Function(map[string]interface{}{"method": "HEAD", "url": s.mockServer.URL() + "/api"})
+		s.NoError(err)
+		s.Contains(result, "HTTP/1.1 200 OK\n")
+		s.Contains(result, "Content-Type: application/json\r\n")
+	})
+	s.Run("Returns invalid headers to the model", func() {
+		result, err := httpRequest.Function(map[string]interface{}{"method": "GET", "url": s.mockServer.URL() + "/echo", "headers": []interface{}{"Invalid"}})
+		s.NoError(err)
+		s.Equal("Invalid header 'Invalid': headers must use the Name: value format.", result)
+	})
+}
+
+func (s *HttpTestSuite) TestDomains() {
+	s.Run("Denies domains not matching the allowed patterns", func() {
+		_, err := s.tool(api.ToolsParameters{AllowedDomains: []string{"*.example.com"}}, "http_get").
+			Function(map[string]interface{}{"url": s.mockServer.URL() + "/api"})
+		s.ErrorAs(err, new(*api.ToolResultError))
+		s.EqualError(err, "Access denied: the domain 127.0.0.1 doesn't match any of the allowed patterns (*.example.com).")
+	})
+	s.Run("Allows domains matching the allowed patterns", func() {
+		result, err := s.tool(api.ToolsParameters{AllowedDomains: []string{"127.0.0.*"}}, "http_get").
+			Function(map[string]interface{}{"url": s.mockServer.URL() + "/api"})
+		s.NoError(err)
+		s.Contains(result, "HTTP/1.1 200 OK")
+	})
+	s.Run("Denies domains matching the denied patterns", func() {
+		_, err := s.tool(api.ToolsParameters{AllowedDomains: []string{"*"}, DeniedDomains: []string{"127.0.0.1"}}, "http_get").
+			Function(map[string]interface{}{"url": s.mockServer.URL() + "/api"})
+		s.ErrorAs(err, new(*api.ToolResultError))
+		s.EqualError(err, "Access denied: the domain 127.0.0.1 matches the denied pattern '127.0.0.1'.")
+	})
+	s.Run("Denies redirects to denied domains", func() {
+		_, err := s.tool(api.ToolsParameters{DeniedDomains: []string{"*.example.com"}}, "http_get").
+			Function(map[string]interface{}{"url": s.mockServer.URL() + "/redirect-external"})
+		var denied *api.ToolResultError
+		s.Require().ErrorAs(err, &denied)
+		s.Equal("Access denied: the domain denied.example.com matches the denied pattern '*.example.com'.", denied.Message)
+	})
+	s.Run("Denies unsupported schemes", func() {
+		result, err := s.tool(api.ToolsParameters{}, "http_get").Function(map[string]interface{}{"url": "file:///etc/passwd"})
+		s.NoError(err)
+		s.Contains(result, "Invalid URL")
+		_, err = s.tool(api.ToolsParameters{}, "http_get").Function(map[string]interface{}{"url": "ftp://example.com/file"})
+		s.ErrorAs(err, new(*api.ToolResultError))
+		s.EqualError(err, "Access denied: unsupported scheme 'ftp', only http and https URLs are allowed.")
+	})
+}
+
+func (s *HttpTestSuite) TestProxy() {
+	proxy := test.NewMockServer()
+	defer proxy.Close()
+	proxy.Handle(func(w http.ResponseWriter, r *http.Request) bool {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("proxied " + r.URL.String()))
+		return true
+	})
+	s.Run("Sends the requests through the configured proxy", func() {
+		result, err := s.tool(api.ToolsParameters{Proxy: utils.Ptr(proxy.URL())}, "http_get").
+			Function(map[string]interface{}{"url": "http://docs.example.com/page"})
+		s.NoError(err)
+		s.True(strings.HasSuffix(result, "\n\nproxied http://docs.example.com/page"), result)
+	})
+	s.Run("Invalid proxy makes the provider unavailable", func() {
+		p := &Provider{}
+		p.Proxy = utils.Ptr("not a url")
+		p.Initialize(s.T().Context())
+		s.False(p.IsAvailable())
+		s.Equal("invalid proxy URL 'not a url'", p.Reason())
+	})
+}
+
+func TestHttp(t *testing.T) {
+	suite.Run(t, new(HttpTestSuite))
+}
@@ -0,0 +1,329 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/keyring"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeConn is a redis.Conn that records the commands and replies with the provided function
+type fakeConn struct {
+	commands []string
+	reply    func(command string, args ...interface{}) (interface{}, error)
+}
+
+func (c *fakeConn) Do(command string, args ...interface{}) (interface{}, error) {
+	recorded := []string{command}
+	for _, arg := range args {
+		recorded = append(recorded, fmt.Sprint(arg))
+	}
+	c.commands = append(c.commands, strings.Join(recorded, " "))
+	return c.reply(command, args...)
+}
+
+func (c *fakeConn) Close() error                          { return nil }
+func (c *fakeConn) Err() error                            { return nil }
+func (c *fakeConn) Send(_ string, _ ...interface{}) error { return errors.New("not implemented") }
+func (c *fakeConn) Flush() error                          { return nil }
+func (c *fakeConn) Receive() (interface{}, error)         { return nil, errors.New("not implemented") }
+func (c *fakeConn) reset(reply func(string, ...interface{}) (interface{}, error)) {
+	c.commands, c.reply = nil, reply
+}
+
+type RedisTestSuite struct {
+	suite.Suite
+	conn            *fakeConn
+	originalDialURL func(string) (redis.Conn, error)
+	dialedURL       string
+}
+
+func (s *RedisTestSuite) SetupTest() {
+	keyring.MockInit()
+	s.conn = &fakeConn{}
+	s.originalDialURL = dialURLFunc
+	dialURLFunc = func(rawURL string) (redis.Conn, error) {
+		s.dialedURL = rawURL
+		return s.conn, nil
+	}
+}
+
+func (s *RedisTestSuite) TearDownTest() {
+	dialURLFunc = s.originalDialURL
+}
+
+func (s *RedisTestSuite) tool(parameters api.ToolsParameters, name string) *api.Tool {
+	p := &Provider{redisURL: "redis://localhost:6379/0"}
+	p.ToolsParameters = parameters
+	for _, t := range p.GetTools(s.T().Context()) {
+		if t.Name == name {
+			return t
+		}
+	}
+	s.FailNow("tool not found", name)
+	return nil
+}
+
+func (s *RedisTestSuite) TestInitialize() {
+	for _, tt := range []struct {
+		redisURL  string
+		available bool
+		reason    string
+	}{
+		{redisURL: "redis://:secret@localhost:6379/0", available: true, reason: "REDIS_URL is set"},
+		{redisURL: "rediss://cache.example.com:6380", available: true, reason: "REDIS_URL is set"},
+		{redisURL: "localhost:6379", available: false, reason: "REDIS_URL is not set with redis or rediss schema"},
+		{redisURL: "", available: false, reason: "REDIS_URL is not set"},
+	} {
+		s.Run(tt.reason+" "+tt.redisURL, func() {
+			s.T().Setenv("REDIS_URL", tt.redisURL)
+			p := &Provider{}
+			p.Initialize(config.WithConfig(s.T().Context(), config.New()))
+			s.Equal(tt.available, p.IsAvailable())
+			s.Equal(tt.reason, p.Reason())
+		})
+	}
+	s.Run("Keyring takes precedence", func() {
+		s.T().Setenv("REDIS_URL", "redis://env:6379")
+		s.Require().NoError(keyring.SetKey("REDIS_URL", "redis://keyring:6379"))
+		p := &Provider{}
+		p.Initialize(config.WithConfig(s.T().Context(), config.New()))
+		s.Equal("redis://keyring:6379", p.redisURL)
+	})
+}
+
+func (s *RedisTestSuite) TestGetTools() {
+	toolNames := func(parameters api.ToolsParameters) []string {
+		p := &Provider{}
+		p.ToolsParameters = parameters
+		var names []string
+		for _, t := range p.GetTools(s.T().Context()) {
+			names = append(names, t.Name)
+		}
+		return names
+	}
+	readTools := []string{"redis_scan", "redis_type", "redis_get", "redis_ttl", "redis_info", "redis_slowlog"}
+	s.Run("All tools by default", func() {
+		s.Equal(append(readTools, "redis_expire", "redis_set", "redis_delete"), toolNames(api.ToolsParameters{}))
+	})
+	s.Run("Read-only hides the write tools", func() {
+		s.Equal(readTools, toolNames(api.ToolsParameters{ReadOnly: utils.Ptr(true)}))
+	})
+	s.Run("Disable destructive hides the write tools", func() {
+		s.Equal(readTools, toolNames(api.ToolsParameters{DisableDestructive: utils.Ptr(true)}))
+	})
+}
+
+func (s *RedisTestSuite) TestRedisScan() {
+	s.Run("Scans until the limit is reached", func() {
+		s.conn.reset(func(_ string, args ...interface{}) (interface{}, error) {
+			if args[0] == "0" {
+				return []interface{}{[]byte("17"), []interface{}{[]byte("user:1"), []byte("user:2")}}, nil
+			}
+			return []interface{}{[]byte("42"), []interface{}{[]byte("user:3")}}, nil
+		})
+		result, err := s.tool(api.ToolsParameters{}, "redis_scan").Function(map[string]interface{}{"pattern": "user:*", "type": "hash", "limit": 3.0})
+		s.NoError(err)
+		s.JSONEq(`{"keys":["user:1","user:2","user:3"],"cursor":"42"}`, result)
+		s.Equal([]string{"SCAN 0 MATCH user:* COUNT 100 TYPE hash", "SCAN 17 MATCH user:* COUNT 100 TYPE hash"}, s.conn.commands)
+		s.Equal("redis://localhost:6379/0", s.dialedURL)
+	})
+	s.Run("Scans until the cursor is 0", func() {
+		s.conn.reset(func(_ string, _ ...interface{}) (interface{}, error) {
+			return []interface{}{[]byte("0"), []interface{}{[]byte("session")}}, nil
+		})
+		result, err := s.tool(api.ToolsParameters{}, "redis_scan").Function(map[string]interface{}{"cursor": "42"})
+		s.NoError(err)
+		s.JSONEq(`{"keys":["session"],"cursor":"0"}`, result)
+		s.Equal([]string{"SCAN 42 MATCH * COUNT 100"}, s.conn.commands)
+	})
+}
+
+func (s *RedisTestSuite) TestRedisGet() {
+	s.Run("Returns strings", func() {
+		s.conn.reset(func(command string, _ ...interface{}) (interface{}, error) {
+			if command == "TYPE" {
+				return "string", nil
+			}
+			return []byte("cached value"), nil
+		})
+		result, err := s.tool(api.ToolsParameters{}, "redis_get").Function(map[string]interface{}{"key": "greeting"})
+		s.NoError(err)
+		s.JSONEq(`{"key":"greeting","type":"string","length":12,"value":"cached value"}`, result)
+	})
+	s.Run("Escapes binary strings and truncates long strings", func() {
+		s.conn.reset(func(command string, args ...interface{}) (interface{}, error) {
+			if command == "TYPE" {
+				return "string", nil
+			}
+			if args[0] == "binary" {
+				return []byte{0xff, 0x00}, nil
+			}
+			return []byte(strings.Repeat("a", maxValueLength+1)), nil
+		})
+		result, err := s.tool(api.ToolsParameters{}, "redis_get").Function(map[string]interface{}{"key": "binary"})
+		s.NoError(err)
+		s.Contains(result, `"value":"\"\\xff\\x00\""`)
+		result, err = s.tool(api.ToolsParameters{}, "redis_get").Function(map[string]interface{}{"key": "long"})
+		s.NoError(err)
+		s.Contains(result, "... [truncated, 10241 bytes]")
+	})
+	s.Run("Returns bounded lists", func() {
+		s.conn.reset(func(command string, _ ...interface{}) (interface{}, error) {
+			switch command {
+			case "TYPE":
+				return "list", nil
+			case "LLEN":
+				return int64(500), nil
+			}
+			return []interface{}{[]byte("job-1"), []byte("job-2")}, nil
+		})
+		result, err := s.tool(api.ToolsParameters{}, "redis_get").Function(map[string]interface{}{"key": "queue", "limit": 2.0})
+		s.NoError(err)
+		s.JSONEq(`{"key":"queue","type":"list","length":500,"value":["job-1","job-2"]}`, result)
+		s.Equal([]string{"TYPE queue", "LLEN queue", "LRANGE queue 0 1"}, s.conn.commands)
+	})
+	s.Run("Returns hashes", func() {
+		s.conn.reset(func(command string, _ ...interface{}) (interface{}, error) {
+			switch command {
+			case "TYPE":
+				return "hash", nil
+			case "HLEN":
+				return int64(2), nil
+			}
+			return []interface{}{[]byte("0"), []interface{}{[]byte("name"), []byte("alice"), []byte("age"), []byte("42")}}, nil
+		})
+		result, err := s.tool(api.ToolsParameters{}, "redis_get").Function(map[string]interface{}{"key": "user:1"})
+		s.NoError(err)
+		s.JSONEq(`{"key":"user:1","type":"hash","length":2,"value":{"name":"alice","age":"42"}}`, result)
+	})
+	s.Run("Returns streams", func() {
+		s.conn.reset(func(command string, _ ...interface{}) (interface{}, error) {
+			switch command {
+			case "TYPE":
+				return "stream", nil
+			case "XLEN":
+				return int64(1), nil
+			}
+			return []interface{}{[]interface{}{[]byte("1-0"), []interface{}{[]byte("event"), []byte("login")}}}, nil
+		})
+		result, err := s.tool(api.ToolsParameters{}, "redis_get").Function(map[string]interface{}{"key": "events"})
+		s.NoError(err)
+		s.JSONEq(`{"key":"events","type":"stream","length":1,"value":[{"id":"1-0","fields":{"event":"login"}}]}`, result)
+	})
+	s.Run("Returns missing keys to the model", func() {
+		s.conn.reset(func(_ string, _ ...interface{}) (interface{}, error) { return "none", nil })
+		result, err := s.tool(api.ToolsParameters{}, "redis_get").Function(map[string]interface{}{"key": "missing"})
+		s.NoError(err)
+		s.Equal("The key missing doesn't exist.", result)
+	})
+}
+
+func (s *RedisTestSuite) TestRedisTtl() {
+	for ttl, expected := range map[int64]string{
+		-2:    "The key session doesn't exist.",
+		-1:    "The key session has no expiration.",
+		90500: "The key session expires in 1m30.5s.",
+	} {
+		s.conn.reset(func(_ string, _ ...interface{}) (interface{}, error) { return ttl, nil })
+		result, err := s.tool(api.ToolsParameters{}, "redis_ttl").Function(map[string]interface{}{"key": "session"})
+		s.NoError(err)
+		s.Equal(expected, result)
+	}
+}
+
+func (s *RedisTestSuite) TestRedisInfoAndSlowlog() {
+	s.Run("Returns the info section", func() {
+		s.conn.reset(func(_ string, _ ...interface{}) (interface{}, error) {
+			return []byte("# Memory\r\nused_memory:1024\r\n"), nil
+		})
+		result, err := s.tool(api.ToolsParameters{}, "redis_info").Function(map[string]interface{}{"section": "memory"})
+		s.NoError(err)
+		s.Equal("# Memory\r\nused_memory:1024\r\n", result)
+		s.Equal([]string{"INFO memory"}, s.conn.commands)
+	})
+	s.Run("Returns the slow log entries", func() {
+		s.conn.reset(func(_ string, _ ...interface{}) (interface{}, error) {
+			return []interface{}{[]interface{}{int64(7), int64(1700000000), int64(15000),
+				[]interface{}{[]byte("KEYS"), []byte("*")}, []byte("127.0.0.1:5000"), []byte("worker")}}, nil
+		})
+		result, err := s.tool(api.ToolsParameters{}, "redis_slowlog").Function(map[string]interface{}{"count": 5.0})
+		s.NoError(err)
+		s.JSONEq(`[{"id":7,"time":"2023-11-14T22:13:20Z","duration_us":15000,"command":"KEYS *","client":"127.0.0.1:5000","client_name":"worker"}]`, result)
+		s.Equal([]string{"SLOWLOG GET 5"}, s.conn.commands)
+	})
+}
+
+func (s *RedisTestSuite) TestWriteTools() {
+	s.conn.reset(func(command string, _ ...interface{}) (interface{}, error) {
+		switch command {
+		case "SET":
+			return "OK", nil
+		case "DEL":
+			return int64(1), nil
+		}
+		return int64(1), nil
+	})
+	result, err := s.tool(api.ToolsParameters{}, "redis_set").Function(map[string]interface{}{"key": "feature", "value": "on", "ttl": 60.0})
+	s.NoError(err)
+	s.Equal("The key feature has been set.", result)
+	result, err = s.tool(api.ToolsParameters{}, "redis_expire").Function(map[string]interface{}{"key": "feature", "ttl": 0.0})
+	s.NoError(err)
+	s.Equal("The expiration of the key feature has been updated.", result)
+	result, err = s.tool(api.ToolsParameters{}, "redis_delete").Function(map[string]interface{}{"keys": []interface{}{"feature", "missing"}})
+	s.NoError(err)
+	s.Equal("1 of 2 keys deleted.", result)
+	s.Equal([]string{"SET feature on EX 60", "PERSIST feature", "DEL feature missing"}, s.conn.commands)
+}
+
+func (s *RedisTestSuite) TestErrors() {
+	s.Run("Returns command errors to the model", func() {
+		s.conn.reset(func(_ string, _ ...interface{}) (interface{}, error) {
+			return nil, redis.Error("NOAUTH Authentication required.")
+		})
+		result, err := s.tool(api.ToolsParameters{}, "redis_type").Function(map[string]interface{}{"key": "any"})
+		s.NoError(err)
+		s.Equal("The Redis command failed: NOAUTH Authentication required.", result)
+	})
+	s.Run("Returns connection errors to the model", func() {
+		dialURLFunc = func(_ string) (redis.Conn, error) { return nil, errors.New("connection refused") }
+		result, err := s.tool(api.ToolsParameters{}, "redis_info").Function(map[string]interface{}{})
+		s.NoError(err)
+		s.Equal("Connecting to Redis failed: connection refused", result)
+	})
+	s.Run("Returns missing arguments to the model", func() {
+		s.conn.reset(func(_ string, _ ...interface{}) (interface{}, error) { return nil, nil })
+		for name, tt := range map[string]struct {
+			args     map[string]interface{}
+			expected string
+		}{
+			"redis_type":   {map[string]interface{}{}, "The key is required."},
+			"redis_get":    {map[string]interface{}{"key": ""}, "The key is required."},
+			"redis_ttl":    {map[string]interface{}{}, "The key is required."},
+			"redis_set":    {map[string]interface{}{"key": "feature"}, "The value is required."},
+			"redis_expire": {map[string]interface{}{"key": "feature"}, "The ttl is required."},
+			"redis_delete": {map[string]interface{}{"keys": []interface{}{}}, "At least one key is required."},
+		} {
+			result, err := s.tool(api.ToolsParameters{}, name).Function(tt.args)
+			s.NoError(err, name)
+			s.Equal(tt.expected, result, name)
+		}
+		s.Empty(s.conn.commands)
+	})
+}
+
+func (s *RedisTestSuite) TestGetContainerURL() {
+	s.Equal("redis://:s3cr%2Ft@localhost:16379", getContainerURL("s3cr/t", "16379"))
+	s.Equal("redis://localhost:16379", getContainerURL("", "16379"))
+}
+
+func TestRedis(t *testing.T) {
+	suite.Run(t, new(RedisTestSuite))
+}
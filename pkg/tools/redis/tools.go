@@ -0,0 +1,493 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/utils"
+)
+
+const (
+	// defaultLimit is the number of keys or elements returned if no limit is provided
+	defaultLimit = 100
+	// maxLimit is the maximum number of keys or elements returned
+	maxLimit = 1000
+	// maxScanIterations is the maximum number of SCAN calls of a redis_scan call
+	maxScanIterations = 100
+	// maxValueLength is the maximum length of a returned value, longer values are truncated
+	maxValueLength = 10 * 1024
+	// defaultSlowlogCount is the number of slow log entries returned if no count is provided
+	defaultSlowlogCount = 10
+)
+
+var keyParameter = api.ToolParameter{
+	Type:        api.String,
+	Description: "The key.",
+	Required:    true,
+}
+
+var limitParameter = api.ToolParameter{
+	Type:        api.Integer,
+	Description: "The maximum number of elements to return.",
+	Default:     defaultLimit,
+	Minimum:     utils.Ptr(1.0),
+	Maximum:     utils.Ptr(float64(maxLimit)),
+}
+
+func (p *Provider) redisScan() *api.Tool {
+	return &api.Tool{
+		Name: "redis_scan",
+		Description: "Find the keys matching a glob-style pattern (without blocking the server). " +
+			"Returns the keys and a cursor, a cursor other than 0 means there are more keys that can be retrieved by providing the cursor.",
+		ReadOnly: true,
+		Parameters: map[string]api.ToolParameter{
+			"pattern": {
+				Type:        api.String,
+				Description: "The glob-style pattern of the keys (e.g. user:*).",
+				Default:     "*",
+			},
+			"type": {
+				Type:        api.String,
+				Description: "Only return the keys of this type.",
+				Enum:        []string{"string", "list", "set", "zset", "hash", "stream"},
+			},
+			"cursor": {
+				Type:        api.String,
+				Description: "The cursor returned by a previous call to continue the scan.",
+				Default:     "0",
+			},
+			"limit": limitParameter,
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			pattern, _ := args["pattern"].(string)
+			if pattern == "" {
+				pattern = "*"
+			}
+			cursor, _ := args["cursor"].(string)
+			if cursor == "" {
+				cursor = "0"
+			}
+			scanArgs := redis.Args{"MATCH", pattern, "COUNT", defaultLimit}
+			if keyType, _ := args["type"].(string); keyType != "" {
+				scanArgs = scanArgs.Add("TYPE", keyType)
+			}
+			limit := limitArg(args)
+			return p.do(func(conn redis.Conn) (string, error) {
+				keys := make([]string, 0)
+				for i := 0; i < maxScanIterations; i++ {
+					reply, err := redis.Values(conn.Do("SCAN", redis.Args{cursor}.AddFlat(scanArgs)...))
+					if err != nil {
+						return "", err
+					}
+					var batch []string
+					if _, err = redis.Scan(reply, &cursor, &batch); err != nil {
+						return "", err
+					}
+					keys = append(keys, batch...)
+					if cursor == "0" || len(keys) >= limit {
+						break
+					}
+				}
+				return toJSON(map[string]any{"keys": keys, "cursor": cursor})
+			})
+		},
+	}
+}
+
+func (p *Provider) redisType() *api.Tool {
+	return &api.Tool{
+		Name:        "redis_type",
+		Description: "Return the type of the value stored at a key (string, list, set, zset, hash, stream), or none if the key doesn't exist.",
+		ReadOnly:    true,
+		Parameters: map[string]api.ToolParameter{
+			"key": keyParameter,
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			key, invalid := keyArg(args)
+			if invalid != "" {
+				return invalid, nil
+			}
+			return p.do(func(conn redis.Conn) (string, error) {
+				return redis.String(conn.Do("TYPE", key))
+			})
+		},
+	}
+}
+
+func (p *Provider) redisGet() *api.Tool {
+	return &api.Tool{
+		Name: "redis_get",
+		Description: "Return the value stored at a key of any type (string, list, set, zset, hash or stream) with its type and length. " +
+			"The number of returned elements is limited, long values are truncated.",
+		ReadOnly: true,
+		Parameters: map[string]api.ToolParameter{
+			"key":   keyParameter,
+			"limit": limitParameter,
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			key, invalid := keyArg(args)
+			if invalid != "" {
+				return invalid, nil
+			}
+			limit := limitArg(args)
+			return p.do(func(conn redis.Conn) (string, error) {
+				keyType, err := redis.String(conn.Do("TYPE", key))
+				if err != nil {
+					return "", err
+				}
+				result := map[string]any{"key": key, "type": keyType}
+				var length int64
+				var value any
+				switch keyType {
+				case "none":
+					return fmt.Sprintf("The key %s doesn't exist.", key), nil
+				case "string":
+					var data []byte
+					if data, err = redis.Bytes(conn.Do("GET", key)); err == nil {
+						length, value = int64(len(data)), text(data)
+					}
+				case "list":
+					if length, err = redis.Int64(conn.Do("LLEN", key)); err == nil {
+						value, err = texts(conn.Do("LRANGE", key, 0, limit-1))
+					}
+				case "set":
+					if length, err = redis.Int64(conn.Do("SCARD", key)); err == nil {
+						value, err = texts(conn.Do("SRANDMEMBER", key, limit))
+					}
+				case "zset":
+					if length, err = redis.Int64(conn.Do("ZCARD", key)); err == nil {
+						value, err = texts(conn.Do("ZRANGE", key, 0, limit-1, "WITHSCORES"))
+					}
+				case "hash":
+					if length, err = redis.Int64(conn.Do("HLEN", key)); err == nil {
+						value, err = hashFields(conn, key, limit)
+					}
+				case "stream":
+					if length, err = redis.Int64(conn.Do("XLEN", key)); err == nil {
+						value, err = streamEntries(conn.Do("XRANGE", key, "-", "+", "COUNT", limit))
+					}
+				default:
+					return fmt.Sprintf("The key %s has an unsupported type: %s.", key, keyType), nil
+				}
+				if err != nil {
+					return "", err
+				}
+				result["length"] = length
+				result["value"] = value
+				return toJSON(result)
+			})
+		},
+	}
+}
+
+func (p *Provider) redisTtl() *api.Tool {
+	return &api.Tool{
+		Name:        "redis_ttl",
+		Description: "Return the remaining time to live of a key.",
+		ReadOnly:    true,
+		Parameters: map[string]api.ToolParameter{
+			"key": keyParameter,
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			key, invalid := keyArg(args)
+			if invalid != "" {
+				return invalid, nil
+			}
+			return p.do(func(conn redis.Conn) (string, error) {
+				ttl, err := redis.Int64(conn.Do("PTTL", key))
+				switch {
+				case err != nil:
+					return "", err
+				case ttl == -2:
+					return fmt.Sprintf("The key %s doesn't exist.", key), nil
+				case ttl == -1:
+					return fmt.Sprintf("The key %s has no expiration.", key), nil
+				}
+				return fmt.Sprintf("The key %s expires in %s.", key, time.Duration(ttl)*time.Millisecond), nil
+			})
+		},
+	}
+}
+
+func (p *Provider) redisInfo() *api.Tool {
+	return &api.Tool{
+		Name:        "redis_info",
+		Description: "Return information and statistics about the Redis server (version, clients, memory, persistence, replication, keyspace...).",
+		ReadOnly:    true,
+		Parameters: map[string]api.ToolParameter{
+			"section": {
+				Type:        api.String,
+				Description: "Only return this section (e.g. server, clients, memory, persistence, stats, replication, cpu, keyspace).",
+			},
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			var infoArgs []interface{}
+			if section, _ := args["section"].(string); section != "" {
+				infoArgs = append(infoArgs, section)
+			}
+			return p.do(func(conn redis.Conn) (string, error) {
+				return redis.String(conn.Do("INFO", infoArgs...))
+			})
+		},
+	}
+}
+
+func (p *Provider) redisSlowlog() *api.Tool {
+	return &api.Tool{
+		Name:        "redis_slowlog",
+		Description: "Return the latest entries of the Redis slow log (the commands that exceeded the configured execution time).",
+		ReadOnly:    true,
+		Parameters: map[string]api.ToolParameter{
+			"count": {
+				Type:        api.Integer,
+				Description: "The number of entries to return.",
+				Default:     defaultSlowlogCount,
+				Minimum:     utils.Ptr(1.0),
+				Maximum:     utils.Ptr(float64(maxLimit)),
+			},
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			count := defaultSlowlogCount
+			if c, ok := args["count"].(float64); ok && c > 0 {
+				count = min(int(c), maxLimit)
+			}
+			return p.do(func(conn redis.Conn) (string, error) {
+				slowlogs, err := redis.SlowLogs(conn.Do("SLOWLOG", "GET", count))
+				if err != nil {
+					return "", err
+				}
+				entries := make([]map[string]any, 0, len(slowlogs))
+				for _, slowlog := range slowlogs {
+					entries = append(entries, map[string]any{
+						"id":          slowlog.ID,
+						"time":        slowlog.Time.UTC().Format(time.RFC3339),
+						"duration_us": slowlog.ExecutionTime.Microseconds(),
+						"command":     strings.Join(slowlog.Args, " "),
+						"client":      slowlog.ClientAddr,
+						"client_name": slowlog.ClientName,
+					})
+				}
+				return toJSON(entries)
+			})
+		},
+	}
+}
+
+func (p *Provider) redisSet() *api.Tool {
+	return &api.Tool{
+		Name:        "redis_set",
+		Description: "Set the string value of a key, optionally with an expiration. Any previous value (of any type) is overwritten.",
+		Destructive: true,
+		Parameters: map[string]api.ToolParameter{
+			"key": keyParameter,
+			"value": {
+				Type:        api.String,
+				Description: "The value.",
+				Required:    true,
+			},
+			"ttl": {
+				Type:        api.Integer,
+				Description: "The time to live of the key in seconds (no expiration if not provided).",
+				Minimum:     utils.Ptr(1.0),
+			},
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			key, invalid := keyArg(args)
+			if invalid != "" {
+				return invalid, nil
+			}
+			value, ok := args["value"].(string)
+			if !ok {
+				return "The value is required.", nil
+			}
+			setArgs := redis.Args{key, value}
+			if ttl, ok := args["ttl"].(float64); ok && ttl > 0 {
+				setArgs = setArgs.Add("EX", int64(ttl))
+			}
+			return p.do(func(conn redis.Conn) (string, error) {
+				if _, err := conn.Do("SET", setArgs...); err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("The key %s has been set.", key), nil
+			})
+		},
+	}
+}
+
+func (p *Provider) redisExpire() *api.Tool {
+	return &api.Tool{
+		Name:        "redis_expire",
+		Description: "Set the time to live of a key, or remove its expiration if the ttl is 0.",
+		Destructive: true,
+		Parameters: map[string]api.ToolParameter{
+			"key": keyParameter,
+			"ttl": {
+				Type:        api.Integer,
+				Description: "The time to live of the key in seconds, 0 removes the expiration.",
+				Required:    true,
+				Minimum:     utils.Ptr(0.0),
+			},
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			key, invalid := keyArg(args)
+			if invalid != "" {
+				return invalid, nil
+			}
+			ttl, ok := args["ttl"].(float64)
+			if !ok {
+				return "The ttl is required.", nil
+			}
+			return p.do(func(conn redis.Conn) (string, error) {
+				var updated bool
+				var err error
+				if ttl <= 0 {
+					updated, err = redis.Bool(conn.Do("PERSIST", key))
+				} else {
+					updated, err = redis.Bool(conn.Do("EXPIRE", key, int64(ttl)))
+				}
+				if err != nil {
+					return "", err
+				}
+				if !updated {
+					return fmt.Sprintf("The key %s doesn't exist or its expiration didn't change.", key), nil
+				}
+				return fmt.Sprintf("The expiration of the key %s has been updated.", key), nil
+			})
+		},
+	}
+}
+
+func (p *Provider) redisDelete() *api.Tool {
+	return &api.Tool{
+		Name:        "redis_delete",
+		Description: "Delete one or more keys.",
+		Destructive: true,
+		Parameters: map[string]api.ToolParameter{
+			"keys": {
+				Type:        api.Array,
+				Description: "The keys to delete.",
+				Items:       &api.ToolParameter{Type: api.String},
+				Required:    true,
+			},
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			var keys redis.Args
+			if k, ok := args["keys"].([]interface{}); ok {
+				for _, key := range k {
+					if s, ok := key.(string); ok && s != "" {
+						keys = append(keys, s)
+					}
+				}
+			}
+			if len(keys) == 0 {
+				return "At least one key is required.", nil
+			}
+			return p.do(func(conn redis.Conn) (string, error) {
+				deleted, err := redis.Int64(conn.Do("DEL", keys...))
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d of %d keys deleted.", deleted, len(keys)), nil
+			})
+		},
+	}
+}
+
+// keyArg returns the key argument or an error message to provide to the model if it's missing
+func keyArg(args map[string]interface{}) (string, string) {
+	key, _ := args["key"].(string)
+	if key == "" {
+		return "", "The key is required."
+	}
+	return key, ""
+}
+
+// hashFields returns up to limit fields of the hash
+func hashFields(conn redis.Conn, key string, limit int) (map[string]any, error) {
+	fields := make(map[string]any)
+	cursor := "0"
+	for i := 0; i < maxScanIterations; i++ {
+		reply, err := redis.Values(conn.Do("HSCAN", key, cursor, "COUNT", limit))
+		if err != nil {
+			return nil, err
+		}
+		var batch [][]byte
+		if _, err = redis.Scan(reply, &cursor, &batch); err != nil {
+			return nil, err
+		}
+		for j := 0; j+1 < len(batch) && len(fields) < limit; j += 2 {
+			fields[string(batch[j])] = text(batch[j+1])
+		}
+		if cursor == "0" || len(fields) >= limit {
+			break
+		}
+	}
+	return fields, nil
+}
+
+// streamEntries returns the IDs and fields of the stream entries
+func streamEntries(reply interface{}, err error) ([]map[string]any, error) {
+	values, err := redis.Values(reply, err)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]map[string]any, 0, len(values))
+	for _, value := range values {
+		entry, err := redis.Values(value, nil)
+		if err != nil || len(entry) != 2 {
+			return nil, fmt.Errorf("unexpected stream entry: %v", value)
+		}
+		id, _ := redis.String(entry[0], nil)
+		fieldValues, _ := texts(entry[1], nil)
+		fields := make(map[string]any)
+		for j := 0; j+1 < len(fieldValues); j += 2 {
+			fields[fmt.Sprint(fieldValues[j])] = fieldValues[j+1]
+		}
+		entries = append(entries, map[string]any{"id": id, "fields": fields})
+	}
+	return entries, nil
+}
+
+// texts returns the elements of an array reply as text
+func texts(reply interface{}, err error) ([]any, error) {
+	values, err := redis.ByteSlices(reply, err)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]any, 0, len(values))
+	for _, value := range values {
+		result = append(result, text(value))
+	}
+	return result, nil
+}
+
+// text returns the value as text, binary values are escaped and long values truncated
+func text(value []byte) string {
+	if len(value) > maxValueLength {
+		return strings.ToValidUTF8(string(value[:maxValueLength]), "") + fmt.Sprintf("... [truncated, %d bytes]", len(value))
+	}
+	if !utf8.Valid(value) {
+		return fmt.Sprintf("%q", value)
+	}
+	return string(value)
+}
+
+func limitArg(args map[string]interface{}) int {
+	if l, ok := args["limit"].(float64); ok && l > 0 {
+		return min(int(l), maxLimit)
+	}
+	return defaultLimit
+}
+
+func toJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/manusa/ai-cli/pkg/api"
+)
+
+// AllowedRoots returns the absolute paths (with symlinks resolved) of the roots or the current working directory if
+// none is provided
+func AllowedRoots(roots []string) []string {
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+	resolvedRoots := make([]string, 0, len(roots))
+	for _, root := range roots {
+		if resolved, err := absolutePath(root); err == nil {
+			resolvedRoots = append(resolvedRoots, resolved)
+		}
+	}
+	return resolvedRoots
+}
+
+// ResolvePath returns the absolute path (with symlinks resolved) if it's inside one of the roots (see AllowedRoots),
+// or an access denied api.ToolResultError otherwise.
+// Relative paths (including .. traversals) are resolved before checking them, so they can't escape the roots.
+func ResolvePath(path string, roots []string) (string, error) {
+	allowedRoots := AllowedRoots(roots)
+	resolved, err := absolutePath(path)
+	if err == nil {
+		for _, root := range allowedRoots {
+			if resolved == root || strings.HasPrefix(resolved, strings.TrimSuffix(root, string(filepath.Separator))+string(filepath.Separator)) {
+				return resolved, nil
+			}
+		}
+	}
+	return "", api.NewDeniedError("Access", fmt.Sprintf("%s is outside the allowed directories (%s)", path, strings.Join(allowedRoots, ", ")))
+}
+
+// absolutePath returns the absolute path with the home directory (~) expanded and the symlinks resolved
+func absolutePath(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return evalSymlinks(abs), nil
+}
+
+// evalSymlinks resolves the symlinks of the longest existing part of the path (the rest of the path might not exist
+// yet, e.g. a file to be created)
+func evalSymlinks(path string) string {
+	existing, rest := path, ""
+	for {
+		if resolved, err := filepath.EvalSymlinks(existing); err == nil {
+			return filepath.Join(resolved, rest)
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return path
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+}
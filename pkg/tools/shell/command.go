@@ -0,0 +1,208 @@
+package shell
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/manusa/ai-cli/pkg/api"
+)
+
+// readOnlyCommands are the command patterns allowed for read-only toolsets
+var readOnlyCommands = []string{
+	"cat", "date", "df", "du", "echo", "file", "free", "grep", "egrep", "fgrep", "head", "hostname", "id",
+	"journalctl", "ls", "lsblk", "lsof", "printenv", "ps", "pwd", "stat", "tail", "tree", "uname", "uptime", "wc",
+	"which", "whoami",
+	"git status", "git log", "git diff", "git show", "git branch --show-current", "git remote -v",
+	"docker ps", "docker images", "docker logs", "podman ps", "podman images", "podman logs",
+	"kubectl get", "kubectl describe", "kubectl logs",
+	"systemctl status", "systemctl list-units",
+	"go version", "go env",
+}
+
+// readOnlyDeniedCommands are the options that make the read-only commands modify their environment
+var readOnlyDeniedCommands = []string{
+	"* --output*",
+	"date -s*", "date *--set*",
+	"journalctl *--vacuum*", "journalctl *--rotate*", "journalctl *--flush*", "journalctl *--sync*",
+	"journalctl *--relinquish-var*", "journalctl *--setup-keys*",
+	"go env *-w", "go env *-u",
+	"tree *-o",
+	"file *-C", "file *--compile",
+	// hostname sets the hostname if it's provided (or read from a file), only its bare form is allowed
+	"hostname *",
+}
+
+// reservedWords are the shell keywords that can precede a command
+var reservedWords = []string{"!", "{", "}", "if", "then", "else", "elif", "fi", "do", "done", "while", "until", "time"}
+
+// parsedCommand is a best-effort parsing of a shell command line
+type parsedCommand struct {
+	// segments are the words (unquoted) of each simple command (separated by ;, &&, ||, |, &, newlines or subshells)
+	segments [][]string
+	// redirections are the targets of the output redirections
+	redirections []string
+	// substitution is true if the command contains command or process substitutions
+	substitution bool
+}
+
+func parseCommand(command string) parsedCommand {
+	parsed := parsedCommand{}
+	var words []string
+	word := strings.Builder{}
+	inWord, outputRedirection, inputRedirection := false, false, false
+	flushWord := func() {
+		if !inWord {
+			return
+		}
+		switch {
+		case outputRedirection:
+			parsed.redirections = append(parsed.redirections, word.String())
+		case !inputRedirection:
+			words = append(words, word.String())
+		}
+		word.Reset()
+		inWord, outputRedirection, inputRedirection = false, false, false
+	}
+	flushSegment := func() {
+		flushWord()
+		for len(words) > 0 && slices.Contains(reservedWords, words[0]) {
+			words = words[1:]
+		}
+		if len(words) > 0 {
+			parsed.segments = append(parsed.segments, words)
+		}
+		words = nil
+	}
+	next := func(i int) byte {
+		if i+1 < len(command) {
+			return command[i+1]
+		}
+		return 0
+	}
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case c == '\\' && i+1 < len(command):
+			i++
+			word.WriteByte(command[i])
+			inWord = true
+		case c == '\'':
+			end := strings.IndexByte(command[i+1:], '\'')
+			if end < 0 {
+				end = len(command) - i - 1
+			}
+			word.WriteString(command[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case c == '"':
+			for i++; i < len(command) && command[i] != '"'; i++ {
+				if command[i] == '\\' && i+1 < len(command) {
+					i++
+				} else if command[i] == '`' || (command[i] == '$' && next(i) == '(') {
+					parsed.substitution = true
+				}
+				word.WriteByte(command[i])
+			}
+			inWord = true
+		case c == '`' || (c == '$' && next(i) == '('):
+			parsed.substitution = true
+			word.WriteByte(c)
+			inWord = true
+		case (c == '<' || c == '>') && next(i) == '(':
+			parsed.substitution = true
+			flushSegment()
+		case c == '<' || c == '>':
+			if inWord && strings.Trim(word.String(), "0123456789") == "" {
+				// File descriptor of the redirection (e.g. 2>)
+				word.Reset()
+				inWord = false
+			}
+			flushWord()
+			for next(i) == c {
+				i++
+			}
+			outputRedirection, inputRedirection = c == '>', c == '<'
+			if c == '>' && next(i) == '&' {
+				// Duplication of a file descriptor (e.g. >&2)
+				i++
+				word.WriteByte('&')
+				inWord = true
+			}
+		case c == '&' && next(i) == '>':
+			flushWord()
+			i++
+			outputRedirection = true
+		case strings.IndexByte(";|&\n()", c) >= 0:
+			flushSegment()
+		case c == ' ' || c == '\t' || c == '\r':
+			flushWord()
+		case c == '#' && !inWord:
+			if end := strings.IndexByte(command[i:], '\n'); end >= 0 {
+				i += end - 1
+			} else {
+				i = len(command)
+			}
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	flushSegment()
+	return parsed
+}
+
+// checkCommand returns a command denied api.ToolResultError if the command isn't allowed by the toolset parameters.
+// Every simple command in the command line must be allowed, the checks are best-effort and denied patterns might be
+// circumvented (e.g. with variables), allowed patterns and read-only toolsets should be used to restrict the commands.
+func (p *Provider) checkCommand(command string) error {
+	parsed := parseCommand(command)
+	readOnly := p.ReadOnly != nil && *p.ReadOnly
+	err := func() error {
+		if (readOnly || len(p.AllowedCommands) > 0) && parsed.substitution {
+			return api.NewDeniedError("Command", "command substitutions are not allowed")
+		}
+		if readOnly {
+			for _, target := range parsed.redirections {
+				if target != "/dev/null" && !strings.HasPrefix(target, "&") {
+					return api.NewDeniedError("Command", fmt.Sprintf("output redirection to '%s' is not allowed for read-only toolsets", target))
+				}
+			}
+		}
+		for _, segment := range parsed.segments {
+			simpleCommand := strings.Join(segment, " ")
+			if pattern := matchCommand(p.DeniedCommands, simpleCommand); pattern != "" {
+				return api.NewDeniedError("Command", fmt.Sprintf("'%s' matches the denied pattern '%s'", simpleCommand, pattern))
+			}
+			if readOnly && (matchCommand(readOnlyCommands, simpleCommand) == "" || matchCommand(readOnlyDeniedCommands, simpleCommand) != "") {
+				return api.NewDeniedError("Command", fmt.Sprintf("'%s' is not an allowed read-only command", simpleCommand))
+			}
+			if len(p.AllowedCommands) > 0 && matchCommand(p.AllowedCommands, simpleCommand) == "" {
+				return api.NewDeniedError("Command", fmt.Sprintf("'%s' doesn't match any of the allowed patterns (%s)",
+					simpleCommand, strings.Join(p.AllowedCommands, ", ")))
+			}
+		}
+		return nil
+	}()
+	if err != nil {
+		log.Warn("shell command denied", "command", command, "reason", err.Error())
+	}
+	return err
+}
+
+// matchCommand returns the first pattern matching the command.
+// The * wildcard matches any sequence of characters (including spaces), and a pattern matching the leading words of
+// the command matches the command (e.g. "git status" matches "git status --short").
+func matchCommand(patterns []string, command string) string {
+	for _, pattern := range patterns {
+		expression := regexp.QuoteMeta(strings.TrimSpace(pattern))
+		expression = strings.ReplaceAll(expression, `\*`, ".*")
+		expression = strings.ReplaceAll(expression, `\?`, ".")
+		if matched, _ := regexp.MatchString("^"+expression+`(\s.*)?$`, command); matched {
+			return pattern
+		}
+	}
+	return ""
+}
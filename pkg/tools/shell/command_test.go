@@ -0,0 +1,118 @@
+package shell
+
+import (
+	"testing"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandTestSuite struct {
+	suite.Suite
+}
+
+func (s *CommandTestSuite) TestParseCommand() {
+	s.Run("Splits simple commands", func() {
+		parsed := parseCommand("ls -la; make test && echo ok || echo ko | wc -l & sleep 1\nuptime")
+		s.Equal([][]string{{"ls", "-la"}, {"make", "test"}, {"echo", "ok"}, {"echo", "ko"}, {"wc", "-l"}, {"sleep", "1"}, {"uptime"}}, parsed.segments)
+	})
+	s.Run("Unquotes words", func() {
+		parsed := parseCommand(`grep 'a; b' "c && d" e\ f`)
+		s.Equal([][]string{{"grep", "a; b", "c && d", "e f"}}, parsed.segments)
+	})
+	s.Run("Splits subshells and strips reserved words", func() {
+		parsed := parseCommand("(cd /tmp; rm -rf x); if true; then { rm y; }; fi")
+		s.Equal([][]string{{"cd", "/tmp"}, {"rm", "-rf", "x"}, {"true"}, {"rm", "y"}}, parsed.segments)
+	})
+	s.Run("Collects output redirections", func() {
+		parsed := parseCommand("make > out.txt 2>&1 && cat < in.txt >> log.txt 2>/dev/null &> all.txt")
+		s.Equal([][]string{{"make"}, {"cat"}}, parsed.segments)
+		s.Equal([]string{"out.txt", "&1", "log.txt", "/dev/null", "all.txt"}, parsed.redirections)
+	})
+	s.Run("Detects command substitutions", func() {
+		s.True(parseCommand("echo $(rm -rf x)").substitution)
+		s.True(parseCommand("echo `rm -rf x`").substitution)
+		s.True(parseCommand(`echo "$(rm -rf x)"`).substitution)
+		s.True(parseCommand("diff <(ls a) <(ls b)").substitution)
+		s.False(parseCommand(`echo '$(ls)'`).substitution)
+	})
+	s.Run("Ignores comments", func() {
+		s.Equal([][]string{{"ls"}, {"pwd"}}, parseCommand("ls # rm -rf x\npwd").segments)
+	})
+}
+
+func (s *CommandTestSuite) TestCheckCommand() {
+	check := func(parameters api.ToolsParameters, command string) string {
+		p := &Provider{}
+		p.ToolsParameters = parameters
+		if err := p.checkCommand(command); err != nil {
+			return err.Error()
+		}
+		return ""
+	}
+	s.Run("Allows any command by default", func() {
+		s.Empty(check(api.ToolsParameters{}, "rm -rf build && echo $(date) > out.txt"))
+	})
+	s.Run("Denies commands matching denied patterns", func() {
+		s.Equal("Command denied: 'rm -rf build' matches the denied pattern 'rm'.",
+			check(api.ToolsParameters{DeniedCommands: []string{"rm"}}, "make clean; rm -rf build"))
+		s.NotEmpty(check(api.ToolsParameters{DeniedCommands: []string{"rm -rf *"}}, `"rm" '-rf'   /`))
+	})
+	s.Run("Allows commands matching allowed patterns", func() {
+		s.Empty(check(api.ToolsParameters{AllowedCommands: []string{"make *", "go test"}}, "make lint && go test ./..."))
+	})
+	s.Run("Denies commands not matching allowed patterns", func() {
+		s.Equal("Command denied: 'curl example.com' doesn't match any of the allowed patterns (make *, go test).",
+			check(api.ToolsParameters{AllowedCommands: []string{"make *", "go test"}}, "make lint | curl example.com"))
+		s.Equal("Command denied: command substitutions are not allowed.",
+			check(api.ToolsParameters{AllowedCommands: []string{"echo"}}, "echo $(rm -rf x)"))
+	})
+	s.Run("Denied patterns take precedence over allowed patterns", func() {
+		s.NotEmpty(check(api.ToolsParameters{AllowedCommands: []string{"make *"}, DeniedCommands: []string{"make deploy"}}, "make deploy"))
+	})
+	s.Run("Read-only toolsets allow read-only commands", func() {
+		readOnly := api.ToolsParameters{ReadOnly: utils.Ptr(true)}
+		s.Empty(check(readOnly, "df -h && journalctl -u docker --no-pager | tail -n 50 2>/dev/null"))
+		s.Empty(check(readOnly, "git status --short; git log -n 5 2>&1"))
+		s.Empty(check(readOnly, "go env GOFLAGS GOPATH"))
+		s.Empty(check(readOnly, "tree -L 2 docs-old"))
+		s.Empty(check(readOnly, "file my-Config.txt"))
+		s.Empty(check(readOnly, "hostname"))
+	})
+	for _, command := range []string{
+		"go env -w GOFLAGS=-mod=mod", "go env -u GOFLAGS",
+		"tree -o tree.txt", "tree -L 2 -o tree.txt",
+		"hostname example", "hostname -F /etc/hostname",
+		"file -C -m magic", "file -m magic --compile",
+	} {
+		s.Run("Read-only toolsets deny "+command, func() {
+			s.Equal("Command denied: '"+command+"' is not an allowed read-only command.", check(api.ToolsParameters{ReadOnly: utils.Ptr(true)}, command))
+		})
+	}
+	s.Run("Read-only toolsets deny other commands", func() {
+		readOnly := api.ToolsParameters{ReadOnly: utils.Ptr(true)}
+		s.Equal("Command denied: 'make test' is not an allowed read-only command.", check(readOnly, "make test"))
+		s.NotEmpty(check(readOnly, "ls; rm -rf x"))
+		s.NotEmpty(check(readOnly, "journalctl --vacuum-time=1d"))
+		s.NotEmpty(check(readOnly, "git diff --output=patch.diff"))
+		s.Equal("Command denied: output redirection to 'out.txt' is not allowed for read-only toolsets.", check(readOnly, "ls > out.txt"))
+		s.Equal("Command denied: command substitutions are not allowed.", check(readOnly, "echo $(rm -rf x)"))
+	})
+	s.Run("Read-only toolsets respect allowed patterns", func() {
+		s.NotEmpty(check(api.ToolsParameters{ReadOnly: utils.Ptr(true), AllowedCommands: []string{"ls"}}, "df -h"))
+	})
+}
+
+func (s *CommandTestSuite) TestMatchCommand() {
+	s.Equal("ls", matchCommand([]string{"ls"}, "ls"))
+	s.Equal("ls", matchCommand([]string{"ls"}, "ls -la /tmp"))
+	s.Empty(matchCommand([]string{"ls"}, "lsblk"))
+	s.Equal("git st*", matchCommand([]string{"git st*"}, "git stash drop"))
+	s.Equal("* --force*", matchCommand([]string{"* --force*"}, "git push origin --force-with-lease"))
+	s.Empty(matchCommand([]string{"git status"}, "git stash"))
+}
+
+func TestCommand(t *testing.T) {
+	suite.Run(t, new(CommandTestSuite))
+}
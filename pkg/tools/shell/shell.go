@@ -0,0 +1,215 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/tools"
+	"github.com/manusa/ai-cli/pkg/utils"
+)
+
+const (
+	// defaultTimeout is the default maximum duration in seconds of a command
+	defaultTimeout = 60
+	// maxTimeout is the maximum duration in seconds of a command that the model can request
+	maxTimeout = 600
+	// maxCapturedOutput is the maximum number of bytes captured from the stdout and stderr of a command
+	maxCapturedOutput = 1024 * 1024
+)
+
+type Provider struct {
+	api.BasicToolsProvider
+}
+
+var _ api.ToolsProvider = &Provider{}
+
+func (p *Provider) Initialize(ctx context.Context) {
+	if cfg := config.GetConfig(ctx); cfg != nil {
+		p.ToolsParameters = cfg.ToolsParameters(p.Attributes().Name())
+	}
+	if _, err := exec.LookPath(shell()[0]); err != nil {
+		p.IsAvailableReason = fmt.Sprintf("%s is not available", shell()[0])
+		return
+	}
+	p.Available = true
+	p.IsAvailableReason = fmt.Sprintf("%s is available", shell()[0])
+}
+
+// GetTools returns the shell_exec tool.
+// The tool is read-only for read-only toolsets (only read-only commands are allowed), otherwise it's destructive.
+func (p *Provider) GetTools(_ context.Context) []*api.Tool {
+	readOnly := p.ReadOnly != nil && *p.ReadOnly
+	description := fmt.Sprintf("Run a command in a shell (%s) and return its exit code, stdout and stderr. ", strings.Join(shell(), " ")) +
+		"Commands must be non-interactive and terminate on their own (e.g. use --no-pager, avoid watch modes)."
+	if readOnly {
+		description += " Only read-only commands are allowed (e.g. ls, cat, grep, df, ps, journalctl, git status), " +
+			"output redirections to files and command substitutions are not."
+	}
+	if len(p.AllowedCommands) > 0 {
+		description += fmt.Sprintf(" Only the commands matching these patterns are allowed: %s.", strings.Join(p.AllowedCommands, ", "))
+	}
+	parameters := map[string]api.ToolParameter{
+		"command": {
+			Type:        api.String,
+			Description: "The command to run.",
+			Required:    true,
+		},
+		"working_directory": {
+			Type: api.String,
+			Description: "The directory to run the command in. If not provided, the current working directory will be used. " +
+				fmt.Sprintf("Allowed directories: %s.", strings.Join(tools.AllowedRoots(p.AllowedRoots), ", ")),
+		},
+		"timeout": {
+			Type:        api.Integer,
+			Description: "The maximum duration of the command in seconds, the command is killed if it exceeds it.",
+			Default:     defaultTimeout,
+			Minimum:     utils.Ptr(1.0),
+			Maximum:     utils.Ptr(float64(maxTimeout)),
+		},
+	}
+	if !p.restrictedCommands() {
+		parameters["env"] = api.ToolParameter{
+			Type:        api.Array,
+			Description: "Additional environment variables in the NAME=value format.",
+			Items:       &api.ToolParameter{Type: api.String},
+		}
+	}
+	return []*api.Tool{{
+		Name:        "shell_exec",
+		Description: description,
+		ReadOnly:    readOnly,
+		Destructive: !readOnly,
+		Parameters:  parameters,
+		Function:    p.exec,
+	}}
+}
+
+func (p *Provider) exec(args map[string]interface{}) (string, error) {
+	command, _ := args["command"].(string)
+	if strings.TrimSpace(command) == "" {
+		return "", errors.New("command is required")
+	}
+	if err := p.checkCommand(command); err != nil {
+		return "", err
+	}
+	workingDirectory, _ := args["working_directory"].(string)
+	if workingDirectory == "" {
+		workingDirectory = "."
+	}
+	workingDirectory, err := tools.ResolvePath(workingDirectory, p.AllowedRoots)
+	if err != nil {
+		return "", err
+	}
+	var env []string
+	if e, ok := args["env"].([]interface{}); ok {
+		for _, v := range e {
+			if s, ok := v.(string); ok && strings.Contains(s, "=") {
+				env = append(env, s)
+			}
+		}
+	}
+	if len(env) > 0 && p.restrictedCommands() {
+		// Environment variables can change the behavior of allowed commands (e.g. GIT_EXTERNAL_DIFF, LD_PRELOAD)
+		return "", api.NewDeniedError("Environment", "additional environment variables are not allowed when the commands are restricted")
+	}
+	timeout := defaultTimeout
+	if t, ok := args["timeout"].(float64); ok && t >= 1 {
+		timeout = min(int(t), maxTimeout)
+	}
+	return run(command, workingDirectory, env, time.Duration(timeout)*time.Second)
+}
+
+// restrictedCommands returns true if only some commands can be run (read-only toolsets or allowed commands configured)
+func (p *Provider) restrictedCommands() bool {
+	return (p.ReadOnly != nil && *p.ReadOnly) || len(p.AllowedCommands) > 0
+}
+
+// run runs the command in a shell and returns its exit code, stdout and stderr.
+// A non-zero exit code or a timeout is reported in the result.
+func run(command, workingDirectory string, env []string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, shell()[0], append(shell()[1:], command)...)
+	cmd.Dir = workingDirectory
+	cmd.Env = append(os.Environ(), env...)
+	// Child processes might keep the output pipes open after the shell is killed
+	cmd.WaitDelay = time.Second
+	stdout, stderr := &limitedBuffer{}, &limitedBuffer{}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	err := cmd.Run()
+	timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded)
+	exitCode := 0
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		exitCode = exitErr.ExitCode()
+	case err != nil && !timedOut:
+		return "", err
+	}
+	sb := strings.Builder{}
+	if timedOut {
+		sb.WriteString(fmt.Sprintf("The command timed out after %s and was killed.\n", timeout))
+	}
+	sb.WriteString(fmt.Sprintf("Exit code: %d\n", exitCode))
+	for _, output := range []struct {
+		name   string
+		buffer *limitedBuffer
+	}{{"Stdout", stdout}, {"Stderr", stderr}} {
+		if output.buffer.Len() == 0 {
+			continue
+		}
+		sb.WriteString(output.name + ":\n")
+		sb.WriteString(strings.TrimSuffix(output.buffer.String(), "\n") + "\n")
+		if output.buffer.truncated {
+			sb.WriteString(fmt.Sprintf("[%s truncated: the command produced more than %d bytes]\n", output.name, maxCapturedOutput))
+		}
+	}
+	return sb.String(), nil
+}
+
+// shell returns the command (and arguments) of the shell that runs the commands
+func shell() []string {
+	if runtime.GOOS == "windows" {
+		return []string{"cmd", "/C"}
+	}
+	return []string{"sh", "-c"}
+}
+
+// limitedBuffer captures up to maxCapturedOutput bytes, the rest of the output is discarded
+type limitedBuffer struct {
+	bytes.Buffer
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := maxCapturedOutput - b.Len(); len(p) > remaining {
+		b.truncated = true
+		_, _ = b.Buffer.Write(p[:max(remaining, 0)])
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+var instance = &Provider{
+	api.BasicToolsProvider{
+		BasicToolsAttributes: api.BasicToolsAttributes{
+			BasicFeatureAttributes: api.BasicFeatureAttributes{
+				FeatureName:        "shell",
+				FeatureDescription: "Provides access to a shell to run commands on the local machine.",
+			},
+		},
+	},
+}
+
+func init() {
+	tools.Register(instance)
+}
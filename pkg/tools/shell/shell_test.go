@@ -0,0 +1,122 @@
+package shell
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShellTestSuite struct {
+	suite.Suite
+}
+
+func (s *ShellTestSuite) SetupTest() {
+	if runtime.GOOS == "windows" {
+		s.T().Skip("shell tests require sh")
+	}
+}
+
+func (s *ShellTestSuite) exec(parameters api.ToolsParameters, args map[string]interface{}) (string, error) {
+	p := &Provider{}
+	p.ToolsParameters = parameters
+	return p.GetTools(s.T().Context())[0].Function(args)
+}
+
+func (s *ShellTestSuite) TestGetTools() {
+	s.Run("Destructive by default", func() {
+		p := &Provider{}
+		tool := p.GetTools(s.T().Context())[0]
+		s.Equal("shell_exec", tool.Name)
+		s.False(tool.ReadOnly)
+		s.True(tool.Destructive)
+	})
+	s.Run("Read-only for read-only toolsets", func() {
+		p := &Provider{}
+		p.ReadOnly = utils.Ptr(true)
+		tool := p.GetTools(s.T().Context())[0]
+		s.True(tool.ReadOnly)
+		s.False(tool.Destructive)
+		s.Contains(tool.Description, "Only read-only commands are allowed")
+	})
+	s.Run("Describes the allowed patterns", func() {
+		p := &Provider{}
+		p.AllowedCommands = []string{"make *", "go test"}
+		s.Contains(p.GetTools(s.T().Context())[0].Description, "Only the commands matching these patterns are allowed: make *, go test.")
+	})
+	s.Run("Provides the env parameter only for unrestricted commands", func() {
+		p := &Provider{}
+		s.Contains(p.GetTools(s.T().Context())[0].Parameters, "env")
+		p.ReadOnly = utils.Ptr(true)
+		s.NotContains(p.GetTools(s.T().Context())[0].Parameters, "env")
+		p.ReadOnly = nil
+		p.AllowedCommands = []string{"make *"}
+		s.NotContains(p.GetTools(s.T().Context())[0].Parameters, "env")
+	})
+}
+
+func (s *ShellTestSuite) TestExec() {
+	s.Run("Captures the exit code, stdout and stderr", func() {
+		result, err := s.exec(api.ToolsParameters{}, map[string]interface{}{"command": "echo out; echo err >&2; exit 3"})
+		s.NoError(err)
+		s.Equal("Exit code: 3\nStdout:\nout\nStderr:\nerr\n", result)
+	})
+	s.Run("Runs in the working directory", func() {
+		dir, err := filepath.EvalSymlinks(s.T().TempDir())
+		s.Require().NoError(err)
+		result, err := s.exec(api.ToolsParameters{AllowedRoots: []string{dir}}, map[string]interface{}{"command": "pwd -P", "working_directory": dir})
+		s.NoError(err)
+		s.Equal("Exit code: 0\nStdout:\n"+dir+"\n", result)
+	})
+	s.Run("Denies working directories outside the allowed roots", func() {
+		dir := s.T().TempDir()
+		_, err := s.exec(api.ToolsParameters{AllowedRoots: []string{dir}}, map[string]interface{}{"command": "pwd", "working_directory": filepath.Dir(dir)})
+		s.ErrorAs(err, new(*api.ToolResultError))
+		s.ErrorContains(err, "is outside the allowed directories")
+	})
+	s.Run("Sets the environment variables", func() {
+		result, err := s.exec(api.ToolsParameters{}, map[string]interface{}{"command": "echo $GREETING", "env": []interface{}{"GREETING=hello"}})
+		s.NoError(err)
+		s.Equal("Exit code: 0\nStdout:\nhello\n", result)
+	})
+	s.Run("Denies the environment variables when the commands are restricted", func() {
+		for name, parameters := range map[string]api.ToolsParameters{
+			"read-only":        {ReadOnly: utils.Ptr(true)},
+			"allowed commands": {AllowedCommands: []string{"git diff"}},
+		} {
+			s.Run(name, func() {
+				_, err := s.exec(parameters, map[string]interface{}{"command": "git diff", "env": []interface{}{"GIT_EXTERNAL_DIFF=touch pwned"}})
+				s.ErrorAs(err, new(*api.ToolResultError))
+				s.EqualError(err, "Environment denied: additional environment variables are not allowed when the commands are restricted.")
+			})
+		}
+	})
+	s.Run("Kills the command after the timeout", func() {
+		start := time.Now()
+		result, err := s.exec(api.ToolsParameters{}, map[string]interface{}{"command": "sleep 10", "timeout": float64(1)})
+		s.NoError(err)
+		s.Contains(result, "The command timed out after 1s and was killed.")
+		s.Less(time.Since(start), 5*time.Second)
+	})
+	s.Run("Returns the denial as a tool result error", func() {
+		_, err := s.exec(api.ToolsParameters{ReadOnly: utils.Ptr(true)}, map[string]interface{}{"command": "touch file"})
+		s.ErrorAs(err, new(*api.ToolResultError))
+		s.EqualError(err, "Command denied: 'touch file' is not an allowed read-only command.")
+	})
+	s.Run("Returns error for missing working directory", func() {
+		_, err := s.exec(api.ToolsParameters{}, map[string]interface{}{"command": "ls", "working_directory": "/missing/directory"})
+		s.Error(err)
+	})
+	s.Run("Returns error for missing command", func() {
+		_, err := s.exec(api.ToolsParameters{}, map[string]interface{}{})
+		s.EqualError(err, "command is required")
+	})
+}
+
+func TestShell(t *testing.T) {
+	suite.Run(t, new(ShellTestSuite))
+}
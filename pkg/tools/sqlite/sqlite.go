@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/tools"
+)
+
+type Provider struct {
+	api.BasicToolsProvider
+}
+
+var _ api.ToolsProvider = &Provider{}
+
+const databasesEnvVar = "SQLITE_DATABASES"
+
+func (p *Provider) Initialize(ctx context.Context) {
+	if cfg := config.GetConfig(ctx); cfg != nil {
+		p.ToolsParameters = cfg.ToolsParameters(p.Attributes().Name())
+	}
+	if len(p.Databases) == 0 {
+		p.Databases = filepath.SplitList(os.Getenv(databasesEnvVar))
+	}
+	if len(p.Databases) == 0 {
+		p.IsAvailableReason = "no SQLite databases configured"
+		return
+	}
+	var missing []string
+	for _, database := range p.Databases {
+		path, err := expandPath(database)
+		if err != nil {
+			missing = append(missing, database)
+			continue
+		}
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			missing = append(missing, database)
+		}
+	}
+	if len(missing) == len(p.Databases) {
+		p.IsAvailableReason = fmt.Sprintf("none of the configured SQLite databases exist (%s)", strings.Join(missing, ", "))
+		return
+	}
+	p.Available = true
+	p.IsAvailableReason = fmt.Sprintf("%d SQLite database(s) configured", len(p.Databases)-len(missing))
+}
+
+// GetTools returns the sqlite tools.
+// The query tool is always provided, but it uses a read-only connection for read-only toolsets or if destructive
+// tools are disabled.
+func (p *Provider) GetTools(_ context.Context) []*api.Tool {
+	return []*api.Tool{
+		p.listTables(),
+		p.describeTable(),
+		p.query(),
+	}
+}
+
+// readOnly returns true if the databases must be opened with read-only connections
+func (p *Provider) readOnly() bool {
+	return (p.ReadOnly != nil && *p.ReadOnly) || (p.DisableDestructive != nil && *p.DisableDestructive)
+}
+
+// databaseParameter is the parameter of the database the tools operate on, optional if a single database is configured
+func (p *Provider) databaseParameter() api.ToolParameter {
+	return api.ToolParameter{
+		Type: api.String,
+		Description: fmt.Sprintf("The path of the SQLite database as configured (%s).", strings.Join(p.Databases, ", ")) +
+			" Optional if a single database is configured.",
+		Enum: p.Databases,
+	}
+}
+
+// database returns the absolute path of the database argument if it's one of the configured databases, or an error
+// message to provide to the model
+func (p *Provider) database(args map[string]interface{}) (string, string) {
+	database, _ := args["database"].(string)
+	if database == "" {
+		if len(p.Databases) != 1 {
+			return "", fmt.Sprintf("The database is required, provide one of the configured databases (%s).", strings.Join(p.Databases, ", "))
+		}
+		database = p.Databases[0]
+	}
+	requested, err := expandPath(database)
+	if err == nil {
+		for _, configured := range p.Databases {
+			if path, err := expandPath(configured); err == nil && path == requested {
+				return path, ""
+			}
+		}
+	}
+	return "", fmt.Sprintf("Unknown database '%s', provide one of the configured databases (%s).", database, strings.Join(p.Databases, ", "))
+}
+
+// expandPath returns the absolute and clean path with the home directory (~) expanded
+func expandPath(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+	return filepath.Abs(path)
+}
+
+var instance = &Provider{
+	api.BasicToolsProvider{
+		BasicToolsAttributes: api.BasicToolsAttributes{
+			BasicFeatureAttributes: api.BasicFeatureAttributes{
+				FeatureName:        "sqlite",
+				FeatureDescription: "Provides access to local SQLite database files, allowing inspection of their schema and execution of SQL queries.",
+			},
+		},
+	},
+}
+
+func init() {
+	tools.Register(instance)
+}
@@ -0,0 +1,178 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/manusa/ai-cli/internal/test"
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/config"
+	"github.com/manusa/ai-cli/pkg/policies"
+	"github.com/manusa/ai-cli/pkg/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type SqliteTestSuite struct {
+	suite.Suite
+	database string
+}
+
+func (s *SqliteTestSuite) SetupTest() {
+	s.database = filepath.Join(s.T().TempDir(), "app.db")
+	db, err := sql.Open("sqlite", s.database)
+	s.Require().NoError(err)
+	defer func() { _ = db.Close() }()
+	_, err = db.Exec(`
+		CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, avatar BLOB);
+		CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users(id) ON DELETE CASCADE, total REAL);
+		CREATE UNIQUE INDEX users_name ON users(name);
+		CREATE VIEW big_orders AS SELECT * FROM orders WHERE total > 100;
+		INSERT INTO users (name, avatar) VALUES ('alice', x'89504e4700ff'), ('bob', NULL), ('carol', NULL);
+		INSERT INTO orders (user_id, total) VALUES (1, 50.5), (1, 150);
+	`)
+	s.Require().NoError(err)
+}
+
+func (s *SqliteTestSuite) tool(parameters api.ToolsParameters, name string) *api.Tool {
+	p := &Provider{}
+	p.ToolsParameters = parameters
+	for _, t := range p.GetTools(s.T().Context()) {
+		if t.Name == name {
+			return t
+		}
+	}
+	s.FailNow("tool not found", name)
+	return nil
+}
+
+func (s *SqliteTestSuite) TestInitialize() {
+	s.Run("Not available without databases", func() {
+		p := &Provider{}
+		p.Initialize(s.T().Context())
+		s.False(p.IsAvailable())
+		s.Equal("no SQLite databases configured", p.Reason())
+	})
+	s.Run("Not available if the databases don't exist", func() {
+		p := &Provider{}
+		p.Databases = []string{"/missing.db"}
+		p.Initialize(s.T().Context())
+		s.False(p.IsAvailable())
+		s.Equal("none of the configured SQLite databases exist (/missing.db)", p.Reason())
+	})
+	s.Run("Available with existing databases", func() {
+		p := &Provider{}
+		p.Databases = []string{s.database, "/missing.db"}
+		p.Initialize(s.T().Context())
+		s.True(p.IsAvailable())
+		s.Equal("1 SQLite database(s) configured", p.Reason())
+	})
+	s.Run("Available with the databases of the policies", func() {
+		cfg := config.New()
+		cfg.Enforce(test.Must(policies.ReadToml(fmt.Sprintf("[tools.provider.sqlite]\ndatabases = [%q]\n", s.database))))
+		p := &Provider{}
+		p.BasicToolsAttributes = instance.BasicToolsAttributes
+		p.Initialize(config.WithConfig(s.T().Context(), cfg))
+		s.True(p.IsAvailable())
+		s.Equal([]string{s.database}, p.Databases)
+	})
+	s.Run("Available with the databases of the SQLITE_DATABASES environment variable", func() {
+		s.T().Setenv("SQLITE_DATABASES", strings.Join([]string{s.database, "/missing.db"}, string(filepath.ListSeparator)))
+		p := &Provider{}
+		p.Initialize(config.WithConfig(s.T().Context(), config.New()))
+		s.True(p.IsAvailable())
+		s.Equal([]string{s.database, "/missing.db"}, p.Databases)
+	})
+}
+
+func (s *SqliteTestSuite) TestListTables() {
+	s.Run("Lists the tables and views", func() {
+		result, err := s.tool(api.ToolsParameters{Databases: []string{s.database}}, "list_tables").Function(map[string]interface{}{})
+		s.NoError(err)
+		s.JSONEq(`{"columns":["name","type"],"rows":[["big_orders","view"],["orders","table"],["users","table"]]}`, result)
+	})
+	s.Run("Requires the database if several are configured", func() {
+		result, err := s.tool(api.ToolsParameters{Databases: []string{s.database, "other.db"}}, "list_tables").Function(map[string]interface{}{})
+		s.NoError(err)
+		s.Equal("The database is required, provide one of the configured databases ("+s.database+", other.db).", result)
+	})
+	s.Run("Denies databases that are not configured", func() {
+		result, err := s.tool(api.ToolsParameters{Databases: []string{s.database}}, "list_tables").
+			Function(map[string]interface{}{"database": "/etc/other.db"})
+		s.NoError(err)
+		s.Equal("Unknown database '/etc/other.db', provide one of the configured databases ("+s.database+").", result)
+	})
+}
+
+func (s *SqliteTestSuite) TestDescribeTable() {
+	describeTable := s.tool(api.ToolsParameters{Databases: []string{s.database}}, "describe_table")
+	s.Run("Describes the columns, indexes and foreign keys", func() {
+		result, err := describeTable.Function(map[string]interface{}{"database": s.database, "table": "orders"})
+		s.NoError(err)
+		s.Contains(result, `"sql":"CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users(id) ON DELETE CASCADE, total REAL)"`)
+		s.Contains(result, `"columns":{"columns":["name","type","not_null","default_value","primary_key"],"rows":[["id","INTEGER",0,null,1],["user_id","INTEGER",0,null,0],["total","REAL",0,null,0]]}`)
+		s.Contains(result, `"foreign_keys":{"columns":["from","table","to","on_update","on_delete"],"rows":[["user_id","users","id","NO ACTION","CASCADE"]]}`)
+	})
+	s.Run("Returns missing tables to the model", func() {
+		result, err := describeTable.Function(map[string]interface{}{"table": "missing"})
+		s.NoError(err)
+		s.Equal("The statement failed: no such table: missing", result)
+	})
+}
+
+func (s *SqliteTestSuite) TestQuery() {
+	s.Run("Returns the rows as tabular JSON", func() {
+		result, err := s.tool(api.ToolsParameters{Databases: []string{s.database}}, "query").
+			Function(map[string]interface{}{"sql": "SELECT id, name, avatar FROM users ORDER BY id"})
+		s.NoError(err)
+		s.JSONEq(`{"columns":["id","name","avatar"],"rows":[[1,"alice","[BLOB 6 bytes]"],[2,"bob",null],[3,"carol",null]]}`, result)
+	})
+	s.Run("Bounds the number of rows", func() {
+		result, err := s.tool(api.ToolsParameters{Databases: []string{s.database}}, "query").
+			Function(map[string]interface{}{"sql": "SELECT name FROM users ORDER BY id", "max_rows": 2.0})
+		s.NoError(err)
+		s.JSONEq(`{"columns":["name"],"rows":[["alice"],["bob"]],"truncated":true}`, result)
+	})
+	s.Run("Truncates long values", func() {
+		result, err := s.tool(api.ToolsParameters{Databases: []string{s.database}}, "query").
+			Function(map[string]interface{}{"sql": "SELECT printf('%.2000c', 'a') AS long"})
+		s.NoError(err)
+		s.Contains(result, strings.Repeat("a", maxValueLength)+"... [truncated, 2000 bytes]")
+	})
+	s.Run("Executes statements that modify the database", func() {
+		query := s.tool(api.ToolsParameters{Databases: []string{s.database}}, "query")
+		s.True(query.Destructive)
+		result, err := query.Function(map[string]interface{}{"sql": "UPDATE orders SET total = total + 1"})
+		s.NoError(err)
+		s.Equal(`{"rows_affected":2}`, result)
+	})
+	s.Run("Uses read-only connections for read-only toolsets", func() {
+		query := s.tool(api.ToolsParameters{Databases: []string{s.database}, ReadOnly: utils.Ptr(true)}, "query")
+		s.True(query.ReadOnly)
+		s.False(query.Destructive)
+		result, err := query.Function(map[string]interface{}{"sql": "DELETE FROM orders"})
+		s.NoError(err)
+		s.True(strings.HasPrefix(result, "The statement failed: "), result)
+		result, err = query.Function(map[string]interface{}{"sql": "SELECT count(*) AS count FROM orders"})
+		s.NoError(err)
+		s.JSONEq(`{"columns":["count"],"rows":[[2]]}`, result)
+	})
+	s.Run("Uses read-only connections if destructive tools are disabled", func() {
+		result, err := s.tool(api.ToolsParameters{Databases: []string{s.database}, DisableDestructive: utils.Ptr(true)}, "query").
+			Function(map[string]interface{}{"sql": "DROP TABLE orders"})
+		s.NoError(err)
+		s.True(strings.HasPrefix(result, "The statement failed: "), result)
+	})
+	s.Run("Returns SQL errors to the model", func() {
+		result, err := s.tool(api.ToolsParameters{Databases: []string{s.database}}, "query").
+			Function(map[string]interface{}{"sql": "SELECT * FROM missing"})
+		s.NoError(err)
+		s.Equal("The statement failed: SQL logic error: no such table: missing (1)", result)
+	})
+}
+
+func TestSqlite(t *testing.T) {
+	suite.Run(t, new(SqliteTestSuite))
+}
@@ -0,0 +1,248 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/utils"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	// defaultMaxRows is the number of rows returned by query if no max_rows is provided
+	defaultMaxRows = 100
+	// maxRows is the maximum number of rows returned by query
+	maxRows = 1000
+	// maxValueLength is the maximum length of a text value, longer values are truncated
+	maxValueLength = 1024
+	// queryTimeout is the maximum duration of a statement
+	queryTimeout = 30 * time.Second
+)
+
+// queryStatements are the keywords of the statements that return rows (the rest are executed)
+var queryStatements = []string{"select", "with", "pragma", "explain", "values"}
+
+// Result is the tabular result of a query
+type Result struct {
+	Columns   []string        `json:"columns"`
+	Rows      [][]interface{} `json:"rows"`
+	Truncated bool            `json:"truncated,omitempty"`
+}
+
+func (p *Provider) listTables() *api.Tool {
+	return &api.Tool{
+		Name:        "list_tables",
+		Description: "List the tables and views of a SQLite database.",
+		ReadOnly:    true,
+		Parameters: map[string]api.ToolParameter{
+			"database": p.databaseParameter(),
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			return p.withDatabase(args, true, func(ctx context.Context, db *sql.DB) (any, error) {
+				return query(ctx, db, maxRows, "SELECT name, type FROM sqlite_schema WHERE type IN ('table', 'view') "+
+					"AND name NOT LIKE 'sqlite_%' ORDER BY name")
+			})
+		},
+	}
+}
+
+func (p *Provider) describeTable() *api.Tool {
+	return &api.Tool{
+		Name:        "describe_table",
+		Description: "Describe a table or view of a SQLite database: its definition, columns, indexes and foreign keys.",
+		ReadOnly:    true,
+		Parameters: map[string]api.ToolParameter{
+			"database": p.databaseParameter(),
+			"table": {
+				Type:        api.String,
+				Description: "The name of the table or view.",
+				Required:    true,
+			},
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			table, _ := args["table"].(string)
+			if table == "" {
+				return "The table is required.", nil
+			}
+			return p.withDatabase(args, true, func(ctx context.Context, db *sql.DB) (any, error) {
+				var definition string
+				err := db.QueryRowContext(ctx, "SELECT sql FROM sqlite_schema WHERE type IN ('table', 'view') AND name = ?", table).
+					Scan(&definition)
+				if err == sql.ErrNoRows {
+					return nil, fmt.Errorf("no such table: %s", table)
+				} else if err != nil {
+					return nil, err
+				}
+				description := map[string]any{"table": table, "sql": definition}
+				for key, pragma := range map[string]string{
+					"columns":      `SELECT name, type, "notnull" AS not_null, dflt_value AS default_value, pk AS primary_key FROM pragma_table_info(?)`,
+					"indexes":      `SELECT name, "unique", origin FROM pragma_index_list(?)`,
+					"foreign_keys": `SELECT "from", "table", "to", on_update, on_delete FROM pragma_foreign_key_list(?)`,
+				} {
+					if description[key], err = query(ctx, db, maxRows, pragma, table); err != nil {
+						return nil, err
+					}
+				}
+				return description, nil
+			})
+		},
+	}
+}
+
+func (p *Provider) query() *api.Tool {
+	description := "Run a SQL statement on a SQLite database. Statements returning rows (SELECT, WITH, PRAGMA, EXPLAIN, VALUES) " +
+		"return the columns and rows as JSON, the rest return the number of affected rows."
+	if p.readOnly() {
+		description += " The database is opened in read-only mode, statements that modify the database fail."
+	}
+	return &api.Tool{
+		Name:        "query",
+		Description: description,
+		ReadOnly:    p.readOnly(),
+		Destructive: !p.readOnly(),
+		Parameters: map[string]api.ToolParameter{
+			"database": p.databaseParameter(),
+			"sql": {
+				Type:        api.String,
+				Description: "The SQL statement.",
+				Required:    true,
+			},
+			"max_rows": {
+				Type:        api.Integer,
+				Description: "The maximum number of rows to return.",
+				Default:     defaultMaxRows,
+				Minimum:     utils.Ptr(1.0),
+				Maximum:     utils.Ptr(float64(maxRows)),
+			},
+		},
+		Function: func(args map[string]interface{}) (string, error) {
+			statement, _ := args["sql"].(string)
+			statement = strings.TrimSpace(statement)
+			if statement == "" {
+				return "The sql statement is required.", nil
+			}
+			limit := defaultMaxRows
+			if m, ok := args["max_rows"].(float64); ok && m > 0 {
+				limit = min(int(m), maxRows)
+			}
+			return p.withDatabase(args, p.readOnly(), func(ctx context.Context, db *sql.DB) (any, error) {
+				keyword, _, _ := strings.Cut(strings.ToLower(strings.Fields(statement)[0]), "(")
+				for _, queryStatement := range queryStatements {
+					if keyword == queryStatement {
+						return query(ctx, db, limit, statement)
+					}
+				}
+				result, err := db.ExecContext(ctx, statement)
+				if err != nil {
+					return nil, err
+				}
+				rowsAffected, _ := result.RowsAffected()
+				return map[string]int64{"rows_affected": rowsAffected}, nil
+			})
+		},
+	}
+}
+
+// withDatabase opens the database of the arguments, runs the function and returns its result as JSON.
+// Invalid databases and SQL errors are reported in the result.
+func (p *Provider) withDatabase(args map[string]interface{}, readOnly bool, f func(ctx context.Context, db *sql.DB) (any, error)) (string, error) {
+	path, invalid := p.database(args)
+	if invalid != "" {
+		return invalid, nil
+	}
+	db, err := open(path, readOnly)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = db.Close() }()
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+	result, err := f(ctx, db)
+	if err != nil {
+		return fmt.Sprintf("The statement failed: %s", err), nil
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// open opens the existing database file, read-only connections can't modify the database
+func open(path string, readOnly bool) (*sql.DB, error) {
+	query := url.Values{}
+	query.Add("_pragma", "busy_timeout(5000)")
+	if readOnly {
+		query.Set("mode", "ro")
+		query.Add("_pragma", "query_only(1)")
+	} else {
+		// Don't create the database if it doesn't exist
+		query.Set("mode", "rw")
+	}
+	db, err := sql.Open("sqlite", (&url.URL{Scheme: "file", OmitHost: true, Path: path, RawQuery: query.Encode()}).String())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+// query runs the statement and returns up to limit rows
+func query(ctx context.Context, db *sql.DB, limit int, statement string, args ...any) (*Result, error) {
+	rows, err := db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Columns: columns, Rows: make([][]interface{}, 0)}
+	for rows.Next() {
+		if len(result.Rows) >= limit {
+			result.Truncated = true
+			break
+		}
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err = rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		for i, value := range values {
+			values[i] = jsonValue(value)
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	return result, rows.Err()
+}
+
+// jsonValue returns the value in a JSON friendly representation (text is bounded and blobs are summarized)
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		if !utf8.Valid(v) {
+			return fmt.Sprintf("[BLOB %d bytes]", len(v))
+		}
+		return jsonValue(string(v))
+	case string:
+		if len(v) > maxValueLength {
+			return strings.ToValidUTF8(v[:maxValueLength], "") + fmt.Sprintf("... [truncated, %d bytes]", len(v))
+		}
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,56 @@
+package approval
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/manusa/ai-cli/pkg/ui/context"
+)
+
+const (
+	maxWidth = 80
+	// margin between the dialog and the terminal borders
+	margin = 2
+)
+
+// Approval is the dialog displayed when a destructive tool call requires the user approval
+type Approval interface {
+	tea.ViewModel
+}
+
+type model struct {
+	ctx *context.ModelContext
+}
+
+var _ Approval = (*model)(nil)
+
+func New(ctx *context.ModelContext) Approval {
+	return model{ctx: ctx}
+}
+
+// View renders the pending tool call approval, or an empty string if there's none
+func (m model) View() string {
+	approval := m.ctx.Ai.Session().PendingToolCallApproval()
+	if approval == nil {
+		return ""
+	}
+	style := m.ctx.Theme.ToolCallApproval
+	width := min(maxWidth, m.ctx.Width-margin*2)
+	contentWidth := width - style.GetHorizontalFrameSize()
+	// Long arguments are truncated to keep the dialog within the terminal
+	maxArgumentLines := max(1, m.ctx.Height/2-style.GetVerticalFrameSize()-4)
+	arguments := strings.Split(approval.Arguments, "\n")
+	if len(arguments) > maxArgumentLines {
+		arguments = append(arguments[:maxArgumentLines-1], fmt.Sprintf("… (%d more lines)", len(arguments)-maxArgumentLines+1))
+	}
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().Bold(true).Render("⚠ Approve tool call: "+approval.ToolName),
+		"",
+		lipgloss.NewStyle().MaxWidth(contentWidth).Render(strings.Join(arguments, "\n")),
+		"",
+		lipgloss.NewStyle().Faint(true).Render("[a] approve  [d] deny  [s] always allow for this session"),
+	)
+	return style.Width(width).Render(content)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/manusa/ai-cli/pkg/ai"
 	"github.com/manusa/ai-cli/pkg/api"
+	"github.com/manusa/ai-cli/pkg/ui/components/approval"
 	"github.com/manusa/ai-cli/pkg/ui/components/footer"
 	"github.com/manusa/ai-cli/pkg/ui/components/scrollbar"
 	"github.com/manusa/ai-cli/pkg/ui/context"
@@ -27,6 +28,9 @@ const (
 	minHeight                 = 10
 	chatPaddingRight          = 1
 	composerPaddingHorizontal = 1
+	nestedIndent              = 2
+	composerPlaceholder       = "How can I help you today?"
+	denyReasonPlaceholder     = "Reason for denying the tool call (optional), press enter to send"
 )
 
 type Model struct {
@@ -36,6 +40,9 @@ type Model struct {
 	spinner   spinner.Model
 	composer  textarea.Model
 	footer    tea.ViewModel
+	approval  tea.ViewModel
+	// denyingToolCall is set while the user is typing the reason to deny the pending tool call
+	denyingToolCall bool
 }
 
 func NewModel(ai api.Ai) *Model {
@@ -51,11 +58,12 @@ func NewModel(ai api.Ai) *Model {
 		spinner:   spinner.New(spinner.WithSpinner(spinner.Points)),
 		composer:  textarea.New(),
 		footer:    footer.New(ctx),
+		approval:  approval.New(ctx),
 	}
 	m.viewport.KeyMap = ViewportKeyMap()
 	m.composer.SetHeight(2)
 	m.composer.ShowLineNumbers = false
-	m.composer.Placeholder = "How can I help you today?"
+	m.composer.Placeholder = composerPlaceholder
 	m.composer.Prompt = ""
 	m.composer.SetStyles(ctx.Theme.ComposerStyles)
 	return m
@@ -83,6 +91,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			return m.handleEnter()
 		}
+		if session.PendingToolCallApproval() != nil && !m.denyingToolCall {
+			return m.handleToolCallApproval(msg)
+		}
 		if key := msg.Key(); (key.Code == tea.KeyUp || key.Code == tea.KeyDown) && m.composer.LineCount() > 1 {
 			updateViewport = false
 		}
@@ -101,6 +112,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// AI is running and a new partial message is available
 		// Partial message rendering is handled by the ai.Session itself
 		m.viewport.GotoBottom()
+		if session.PendingToolCallApproval() == nil && m.denyingToolCall {
+			// The tool call is no longer pending (e.g. cancelled)
+			m.resetDenyingToolCall()
+		}
 	}
 	// Update viewport
 	adjustViewportSize(&m)
@@ -115,8 +130,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.viewport, cmd = m.viewport.Update(msg)
 		cmds = append(cmds, cmd)
 	}
-	if !session.IsRunning() {
-		// Ignore input while AI is running
+	if !session.IsRunning() || m.denyingToolCall {
+		// Ignore input while AI is running (unless the user is typing the reason to deny a tool call)
 		m.composer, cmd = m.composer.Update(msg)
 		cmds = append(cmds, cmd)
 	}
@@ -146,10 +161,21 @@ func (m Model) View() string {
 		yOffset := m.viewport.Height() - 1
 		layers = append(layers, lipgloss.NewLayer(m.spinner.View()).X(xOffset).Y(yOffset).Z(2))
 	}
+	// Tool call approval dialog
+	if dialog := m.approval.View(); dialog != "" {
+		xOffset := max(0, (m.context.Width-lipgloss.Width(dialog))/2)
+		yOffset := max(0, m.viewport.Height()-lipgloss.Height(dialog))
+		layers = append(layers, lipgloss.NewLayer(dialog).X(xOffset).Y(yOffset).Z(3))
+	}
 	return lipgloss.NewCanvas(layers...).Render()
 }
 
 func (m Model) handleEnter() (Model, tea.Cmd) {
+	if m.denyingToolCall {
+		m.context.Ai.RespondToolCallApproval(api.ToolCallApprovalResponse{Reason: strings.TrimSpace(m.composer.Value())})
+		m.resetDenyingToolCall()
+		return m, nil
+	}
 	if m.context.Ai.Session().IsRunning() {
 		// AI is running, ignore the input
 		return m, nil
@@ -172,6 +198,26 @@ func (m Model) handleEnter() (Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m Model) handleToolCallApproval(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "a", "y":
+		m.context.Ai.RespondToolCallApproval(api.ToolCallApprovalResponse{Approved: true})
+	case "s":
+		m.context.Ai.RespondToolCallApproval(api.ToolCallApprovalResponse{Approved: true, AlwaysAllow: true})
+	case "d", "n":
+		m.denyingToolCall = true
+		m.composer.Reset()
+		m.composer.Placeholder = denyReasonPlaceholder
+	}
+	return m, nil
+}
+
+func (m *Model) resetDenyingToolCall() {
+	m.denyingToolCall = false
+	m.composer.Reset()
+	m.composer.Placeholder = composerPlaceholder
+}
+
 func (m Model) renderMessages() string {
 	renderedMessages := strings.Builder{}
 	for idx, msg := range m.context.Ai.Session().Messages() {
@@ -208,12 +254,18 @@ func emoji(messageType api.MessageType) string {
 
 func render(context *context.ModelContext, msg api.Message) string {
 	maxWidth := context.Width
-	marginSize := 5
-	guttered := lipgloss.NewStyle().Margin(0, 1, 0, 4).Width(maxWidth - marginSize)
+	// Sub-agent messages are nested (indented) according to their depth
+	indent := msg.Depth * nestedIndent
+	marginSize := 5 + indent
+	guttered := lipgloss.NewStyle().Margin(0, 1, 0, 4+indent).Width(maxWidth - marginSize)
 	switch msg.Type {
 	case api.MessageTypeUser:
-		out := guttered.Render(strings.Trim(msg.Text, "\n"))
-		return out[:1] + "👤" + out[3:]
+		text := strings.Trim(msg.Text, "\n")
+		for _, attachment := range msg.Attachments {
+			text += "\n📎 " + attachment.Name
+		}
+		out := guttered.Render(text)
+		return out[:1+indent] + "👤" + out[3+indent:]
 	case api.MessageTypeTool:
 		return guttered.Render(context.Theme.MessageToolCall.MaxWidth(maxWidth - marginSize).Render("🔧 " + msg.ToolName))
 	case api.MessageTypeAssistant:
@@ -228,9 +280,9 @@ func render(context *context.ModelContext, msg api.Message) string {
 		}
 		if out, err := tr.Render(strings.Trim(msg.Text, "\n")); err == nil {
 			out = guttered.Render(strings.Trim(out, "\n"))
-			return out[:1] + "🤖" + out[3:]
+			return out[:1+indent] + "🤖" + out[3+indent:]
 		}
 	}
-	messageStyle := lipgloss.NewStyle().Width(maxWidth-2).Margin(0, 1)
+	messageStyle := lipgloss.NewStyle().Width(maxWidth-2-indent).Margin(0, 1, 0, 1+indent)
 	return messageStyle.Render(emoji(msg.Type), strings.Trim(msg.Text, "\n"))
 }
@@ -62,6 +62,14 @@ func (s *BaseSuite) SetupTest() {
 				return "file1.txt, file2.txt, file3.txt", nil
 			},
 		},
+		{
+			Name:        "file_delete",
+			Description: "A destructive test tool",
+			Destructive: true,
+			Function: func(args map[string]interface{}) (string, error) {
+				return "file deleted", nil
+			},
+		},
 	}
 	aiAgent := ai.New(&test.InferenceProvider{
 		BasicInferenceProvider: api.BasicInferenceProvider{
@@ -283,7 +291,7 @@ func (s *ModelSuite) TestFooter() {
 	s.Run("Footer displays tools provider count", func() {
 		s.Repaint()
 		teatest.WaitFor(s.T(), s.TM.Output(), func(b []byte) bool {
-			return strings.Contains(string(b), "🛠 0/1")
+			return strings.Contains(string(b), "🛠 0/2")
 		})
 	})
 }
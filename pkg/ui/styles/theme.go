@@ -19,6 +19,7 @@ type Theme struct {
 	ComposerStyles      textarea.Styles
 	GlamourStyle        ansi.StyleConfig
 	MessageToolCall     lipgloss.Style
+	ToolCallApproval    lipgloss.Style
 }
 
 func DefaultTheme(isDark bool) *Theme {
@@ -49,6 +50,10 @@ func DefaultTheme(isDark bool) *Theme {
 		Border(lipgloss.NormalBorder()).
 		BorderForeground(theme.PrimaryBorder).
 		Padding(0, 1)
+	theme.ToolCallApproval = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lightDark(lipgloss.Color("#B8860B"), lipgloss.Color("#FFD700"))).
+		Padding(0, 1)
 	return theme
 }
 
@@ -14,3 +14,8 @@ func GenerateRandomPassword(length int) string {
 func GetFreePort() (port int, err error) {
 	return provider.GetFreePort()
 }
+
+// Ptr returns a pointer to the provided value (e.g. for optional parameters and configuration values)
+func Ptr[T any](v T) *T {
+	return &v
+}